@@ -25,13 +25,21 @@ const (
 )
 
 type Task struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Completed     bool                   `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Completed   bool                   `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	AssigneeId  *string                `protobuf:"bytes,7,opt,name=assignee_id,json=assigneeId,proto3,oneof" json:"assignee_id,omitempty"`
+	Archived    bool                   `protobuf:"varint,8,opt,name=archived,proto3" json:"archived,omitempty"`
+	// Set while the task is completed: when it was last marked done
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// When set, the task is deleted automatically some time after it
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// IDs of the tasks that must be completed before this one can be
+	BlockedBy     []string `protobuf:"bytes,11,rep,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -108,10 +116,51 @@ func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Task) GetAssigneeId() string {
+	if x != nil && x.AssigneeId != nil {
+		return *x.AssigneeId
+	}
+	return ""
+}
+
+func (x *Task) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Task) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Task) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Task) GetBlockedBy() []string {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
+// Maximum title and description lengths are deployment config (MAX_TITLE_LEN,
+// MAX_DESCRIPTION_LEN) enforced by the handlers, so they are not rules here.
 type CreateTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Must be in the future; the handlers check it against their clock
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Every blocker must be an existing task
+	BlockedBy     []string `protobuf:"bytes,4,rep,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -160,11 +209,30 @@ func (x *CreateTaskRequest) GetDescription() string {
 	return ""
 }
 
+func (x *CreateTaskRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetBlockedBy() []string {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
 type UpdateTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Completed     *bool                  `protobuf:"varint,3,opt,name=completed,proto3,oneof" json:"completed,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Title string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// Leaving it out keeps the stored description; "" clears it
+	Description *string `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Completed   *bool   `protobuf:"varint,3,opt,name=completed,proto3,oneof" json:"completed,omitempty"`
+	// Replaces the expiry; leaving it out clears it
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Replaces the blockers; leaving it out clears them
+	BlockedBy     []string `protobuf:"bytes,5,rep,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -207,8 +275,8 @@ func (x *UpdateTaskRequest) GetTitle() string {
 }
 
 func (x *UpdateTaskRequest) GetDescription() string {
-	if x != nil {
-		return x.Description
+	if x != nil && x.Description != nil {
+		return *x.Description
 	}
 	return ""
 }
@@ -220,6 +288,609 @@ func (x *UpdateTaskRequest) GetCompleted() bool {
 	return false
 }
 
+func (x *UpdateTaskRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetBlockedBy() []string {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
+type AssignTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssigneeId    string                 `protobuf:"bytes,1,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignTaskRequest) Reset() {
+	*x = AssignTaskRequest{}
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTaskRequest) ProtoMessage() {}
+
+func (x *AssignTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTaskRequest.ProtoReflect.Descriptor instead.
+func (*AssignTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AssignTaskRequest) GetAssigneeId() string {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return ""
+}
+
+type LookupTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupTasksRequest) Reset() {
+	*x = LookupTasksRequest{}
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupTasksRequest) ProtoMessage() {}
+
+func (x *LookupTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupTasksRequest.ProtoReflect.Descriptor instead.
+func (*LookupTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LookupTasksRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// The changes for one task in a batch patch. Fields left out keep their
+// stored value.
+type TaskChanges struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Completed     *bool                  `protobuf:"varint,4,opt,name=completed,proto3,oneof" json:"completed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskChanges) Reset() {
+	*x = TaskChanges{}
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskChanges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskChanges) ProtoMessage() {}
+
+func (x *TaskChanges) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskChanges.ProtoReflect.Descriptor instead.
+func (*TaskChanges) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TaskChanges) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskChanges) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *TaskChanges) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *TaskChanges) GetCompleted() bool {
+	if x != nil && x.Completed != nil {
+		return *x.Completed
+	}
+	return false
+}
+
+type BatchPatchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Items are validated one by one so each can fail on its own
+	Updates []*TaskChanges `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
+	// Apply every update or none of them
+	Atomic        bool `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchPatchTasksRequest) Reset() {
+	*x = BatchPatchTasksRequest{}
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchPatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPatchTasksRequest) ProtoMessage() {}
+
+func (x *BatchPatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchPatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchPatchTasksRequest) GetUpdates() []*TaskChanges {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+func (x *BatchPatchTasksRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+type BatchCreateTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Items are validated one by one so each can fail on its own
+	Tasks []*CreateTaskRequest `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Create every task or none of them
+	Atomic        bool `protobuf:"varint,2,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateTasksRequest) Reset() {
+	*x = BatchCreateTasksRequest{}
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateTasksRequest) ProtoMessage() {}
+
+func (x *BatchCreateTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateTasksRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchCreateTasksRequest) GetTasks() []*CreateTaskRequest {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *BatchCreateTasksRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+// A client's copy of a task, pushed by POST /api/v1/sync. updated_at is its
+// version: the copy only replaces a stored task that is not newer.
+type SyncTask struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Completed   bool                   `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	AssigneeId  *string                `protobuf:"bytes,5,opt,name=assignee_id,json=assigneeId,proto3,oneof" json:"assignee_id,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Defaults to updated_at for a completed task; ignored for an open one
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	BlockedBy     []string               `protobuf:"bytes,10,rep,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncTask) Reset() {
+	*x = SyncTask{}
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncTask) ProtoMessage() {}
+
+func (x *SyncTask) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncTask.ProtoReflect.Descriptor instead.
+func (*SyncTask) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SyncTask) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SyncTask) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SyncTask) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SyncTask) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *SyncTask) GetAssigneeId() string {
+	if x != nil && x.AssigneeId != nil {
+		return *x.AssigneeId
+	}
+	return ""
+}
+
+func (x *SyncTask) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SyncTask) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *SyncTask) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *SyncTask) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *SyncTask) GetBlockedBy() []string {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
+type SyncTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Items are validated one by one so each can fail on its own
+	Tasks         []*SyncTask `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncTasksRequest) Reset() {
+	*x = SyncTasksRequest{}
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncTasksRequest) ProtoMessage() {}
+
+func (x *SyncTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncTasksRequest.ProtoReflect.Descriptor instead.
+func (*SyncTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SyncTasksRequest) GetTasks() []*SyncTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// A create request without blockers: seeding deletes every task, and the
+// seeded ones only get their IDs on insert, so none could be named
+type SeedTask struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedTask) Reset() {
+	*x = SeedTask{}
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedTask) ProtoMessage() {}
+
+func (x *SeedTask) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedTask.ProtoReflect.Descriptor instead.
+func (*SeedTask) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SeedTask) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SeedTask) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SeedTask) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Replaces every task; without tasks the server's sample set is used
+type SeedTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*SeedTask            `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeedTasksRequest) Reset() {
+	*x = SeedTasksRequest{}
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeedTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedTasksRequest) ProtoMessage() {}
+
+func (x *SeedTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedTasksRequest.ProtoReflect.Descriptor instead.
+func (*SeedTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SeedTasksRequest) GetTasks() []*SeedTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type LookupTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         map[string]*Task       `protobuf:"bytes,1,rep,name=found,proto3" json:"found,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Missing       []string               `protobuf:"bytes,2,rep,name=missing,proto3" json:"missing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupTasksResponse) Reset() {
+	*x = LookupTasksResponse{}
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupTasksResponse) ProtoMessage() {}
+
+func (x *LookupTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupTasksResponse.ProtoReflect.Descriptor instead.
+func (*LookupTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LookupTasksResponse) GetFound() map[string]*Task {
+	if x != nil {
+		return x.Found
+	}
+	return nil
+}
+
+func (x *LookupTasksResponse) GetMissing() []string {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
 type GetTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
@@ -229,7 +900,7 @@ type GetTaskResponse struct {
 
 func (x *GetTaskResponse) Reset() {
 	*x = GetTaskResponse{}
-	mi := &file_api_proto_v1_tasks_proto_msgTypes[3]
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -241,7 +912,7 @@ func (x *GetTaskResponse) String() string {
 func (*GetTaskResponse) ProtoMessage() {}
 
 func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_tasks_proto_msgTypes[3]
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,7 +925,7 @@ func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
 func (*GetTaskResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetTaskResponse) GetTask() *Task {
@@ -273,7 +944,7 @@ type ListTasksResponse struct {
 
 func (x *ListTasksResponse) Reset() {
 	*x = ListTasksResponse{}
-	mi := &file_api_proto_v1_tasks_proto_msgTypes[4]
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -285,7 +956,7 @@ func (x *ListTasksResponse) String() string {
 func (*ListTasksResponse) ProtoMessage() {}
 
 func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1_tasks_proto_msgTypes[4]
+	mi := &file_api_proto_v1_tasks_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -298,7 +969,7 @@ func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
 func (*ListTasksResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_v1_tasks_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListTasksResponse) GetTasks() []*Task {
@@ -312,7 +983,7 @@ var File_api_proto_v1_tasks_proto protoreflect.FileDescriptor
 
 const file_api_proto_v1_tasks_proto_rawDesc = "" +
 	"\n" +
-	"\x18api/proto/v1/tasks.proto\x12\x05tasks\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17validate/validate.proto\"\xe2\x01\n" +
+	"\x18api/proto/v1/tasks.proto\x12\x05tasks\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17validate/validate.proto\"\xcd\x03\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -321,16 +992,89 @@ const file_api_proto_v1_tasks_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"`\n" +
-	"\x11CreateTaskRequest\x12\x1f\n" +
-	"\x05title\x18\x01 \x01(\tB\t\xfaB\x06r\x04\x10\x01\x18dR\x05title\x12*\n" +
-	"\vdescription\x18\x02 \x01(\tB\b\xfaB\x05r\x03\x18\xf4\x03R\vdescription\"\x91\x01\n" +
-	"\x11UpdateTaskRequest\x12\x1f\n" +
-	"\x05title\x18\x01 \x01(\tB\t\xfaB\x06r\x04\x10\x01\x18dR\x05title\x12*\n" +
-	"\vdescription\x18\x02 \x01(\tB\b\xfaB\x05r\x03\x18\xf4\x03R\vdescription\x12!\n" +
-	"\tcompleted\x18\x03 \x01(\bH\x00R\tcompleted\x88\x01\x01B\f\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12$\n" +
+	"\vassignee_id\x18\a \x01(\tH\x00R\n" +
+	"assigneeId\x88\x01\x01\x12\x1a\n" +
+	"\barchived\x18\b \x01(\bR\barchived\x12=\n" +
+	"\fcompleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"blocked_by\x18\v \x03(\tR\tblockedByB\x0e\n" +
+	"\f_assignee_id\"\xc1\x01\n" +
+	"\x11CreateTaskRequest\x12\x1d\n" +
+	"\x05title\x18\x01 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x120\n" +
+	"\n" +
+	"blocked_by\x18\x04 \x03(\tB\x11\xfaB\x0e\x92\x01\v\x10\x14\x18\x01\"\x05r\x03\xb0\x01\x01R\tblockedBy\"\x87\x02\n" +
+	"\x11UpdateTaskRequest\x12\x1d\n" +
+	"\x05title\x18\x01 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\x05title\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12!\n" +
+	"\tcompleted\x18\x03 \x01(\bH\x01R\tcompleted\x88\x01\x01\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x120\n" +
+	"\n" +
+	"blocked_by\x18\x05 \x03(\tB\x11\xfaB\x0e\x92\x01\v\x10\x14\x18\x01\"\x05r\x03\xb0\x01\x01R\tblockedByB\x0e\n" +
+	"\f_descriptionB\f\n" +
+	"\n" +
+	"_completed\"S\n" +
+	"\x11AssignTaskRequest\x12>\n" +
+	"\vassignee_id\x18\x01 \x01(\tB\x1d\xfaB\x1ar\x18\x10\x01\x18@2\x12^[A-Za-z0-9._@-]+$R\n" +
+	"assigneeId\";\n" +
+	"\x12LookupTasksRequest\x12%\n" +
+	"\x03ids\x18\x01 \x03(\tB\x13\xfaB\x10\x92\x01\r\b\x01\x10d\x18\x01\"\x05r\x03\xb0\x01\x01R\x03ids\"\xbd\x01\n" +
+	"\vTaskChanges\x12\x18\n" +
+	"\x02id\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x02id\x12\"\n" +
+	"\x05title\x18\x02 \x01(\tB\a\xfaB\x04r\x02\x10\x01H\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12!\n" +
+	"\tcompleted\x18\x04 \x01(\bH\x02R\tcompleted\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\f\n" +
+	"\n" +
+	"_completed\"q\n" +
+	"\x16BatchPatchTasksRequest\x12?\n" +
+	"\aupdates\x18\x01 \x03(\v2\x12.tasks.TaskChangesB\x11\xfaB\x0e\x92\x01\v\b\x01\x10d\"\x05\x8a\x01\x02\b\x01R\aupdates\x12\x16\n" +
+	"\x06atomic\x18\x02 \x01(\bR\x06atomic\"t\n" +
+	"\x17BatchCreateTasksRequest\x12A\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x18.tasks.CreateTaskRequestB\x11\xfaB\x0e\x92\x01\v\b\x01\x10d\"\x05\x8a\x01\x02\b\x01R\x05tasks\x12\x16\n" +
+	"\x06atomic\x18\x02 \x01(\bR\x06atomic\"\x8e\x04\n" +
+	"\bSyncTask\x12\x18\n" +
+	"\x02id\x18\x01 \x01(\tB\b\xfaB\x05r\x03\xb0\x01\x01R\x02id\x12\x1d\n" +
+	"\x05title\x18\x02 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tcompleted\x18\x04 \x01(\bR\tcompleted\x12C\n" +
+	"\vassignee_id\x18\x05 \x01(\tB\x1d\xfaB\x1ar\x18\x10\x01\x18@2\x12^[A-Za-z0-9._@-]+$H\x00R\n" +
+	"assigneeId\x88\x01\x01\x12C\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\b\xfaB\x05\xb2\x01\x02\b\x01R\tcreatedAt\x12C\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampB\b\xfaB\x05\xb2\x01\x02\b\x01R\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x120\n" +
+	"\n" +
+	"blocked_by\x18\n" +
+	" \x03(\tB\x11\xfaB\x0e\x92\x01\v\x10\x14\x18\x01\"\x05r\x03\xb0\x01\x01R\tblockedByB\x0e\n" +
+	"\f_assignee_id\"L\n" +
+	"\x10SyncTasksRequest\x128\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x0f.tasks.SyncTaskB\x11\xfaB\x0e\x92\x01\v\b\x01\x10d\"\x05\x8a\x01\x02\b\x01R\x05tasks\"\x86\x01\n" +
+	"\bSeedTask\x12\x1d\n" +
+	"\x05title\x18\x01 \x01(\tB\a\xfaB\x04r\x02\x10\x01R\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"D\n" +
+	"\x10SeedTasksRequest\x120\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x0f.tasks.SeedTaskB\t\xfaB\x06\x92\x01\x03\x10\xe8\aR\x05tasks\"\xb3\x01\n" +
+	"\x13LookupTasksResponse\x12;\n" +
+	"\x05found\x18\x01 \x03(\v2%.tasks.LookupTasksResponse.FoundEntryR\x05found\x12\x18\n" +
+	"\amissing\x18\x02 \x03(\tR\amissing\x1aE\n" +
 	"\n" +
-	"_completed\"<\n" +
+	"FoundEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12!\n" +
+	"\x05value\x18\x02 \x01(\v2\v.tasks.TaskR\x05value:\x028\x01\"<\n" +
 	"\x0fGetTaskResponse\x12)\n" +
 	"\x04task\x18\x01 \x01(\v2\v.tasks.TaskB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x04task\"6\n" +
 	"\x11ListTasksResponse\x12!\n" +
@@ -348,25 +1092,51 @@ func file_api_proto_v1_tasks_proto_rawDescGZIP() []byte {
 	return file_api_proto_v1_tasks_proto_rawDescData
 }
 
-var file_api_proto_v1_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_proto_v1_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_api_proto_v1_tasks_proto_goTypes = []any{
-	(*Task)(nil),                  // 0: tasks.Task
-	(*CreateTaskRequest)(nil),     // 1: tasks.CreateTaskRequest
-	(*UpdateTaskRequest)(nil),     // 2: tasks.UpdateTaskRequest
-	(*GetTaskResponse)(nil),       // 3: tasks.GetTaskResponse
-	(*ListTasksResponse)(nil),     // 4: tasks.ListTasksResponse
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*Task)(nil),                    // 0: tasks.Task
+	(*CreateTaskRequest)(nil),       // 1: tasks.CreateTaskRequest
+	(*UpdateTaskRequest)(nil),       // 2: tasks.UpdateTaskRequest
+	(*AssignTaskRequest)(nil),       // 3: tasks.AssignTaskRequest
+	(*LookupTasksRequest)(nil),      // 4: tasks.LookupTasksRequest
+	(*TaskChanges)(nil),             // 5: tasks.TaskChanges
+	(*BatchPatchTasksRequest)(nil),  // 6: tasks.BatchPatchTasksRequest
+	(*BatchCreateTasksRequest)(nil), // 7: tasks.BatchCreateTasksRequest
+	(*SyncTask)(nil),                // 8: tasks.SyncTask
+	(*SyncTasksRequest)(nil),        // 9: tasks.SyncTasksRequest
+	(*SeedTask)(nil),                // 10: tasks.SeedTask
+	(*SeedTasksRequest)(nil),        // 11: tasks.SeedTasksRequest
+	(*LookupTasksResponse)(nil),     // 12: tasks.LookupTasksResponse
+	(*GetTaskResponse)(nil),         // 13: tasks.GetTaskResponse
+	(*ListTasksResponse)(nil),       // 14: tasks.ListTasksResponse
+	nil,                             // 15: tasks.LookupTasksResponse.FoundEntry
+	(*timestamppb.Timestamp)(nil),   // 16: google.protobuf.Timestamp
 }
 var file_api_proto_v1_tasks_proto_depIdxs = []int32{
-	5, // 0: tasks.Task.created_at:type_name -> google.protobuf.Timestamp
-	5, // 1: tasks.Task.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 2: tasks.GetTaskResponse.task:type_name -> tasks.Task
-	0, // 3: tasks.ListTasksResponse.tasks:type_name -> tasks.Task
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	16, // 0: tasks.Task.created_at:type_name -> google.protobuf.Timestamp
+	16, // 1: tasks.Task.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 2: tasks.Task.completed_at:type_name -> google.protobuf.Timestamp
+	16, // 3: tasks.Task.expires_at:type_name -> google.protobuf.Timestamp
+	16, // 4: tasks.CreateTaskRequest.expires_at:type_name -> google.protobuf.Timestamp
+	16, // 5: tasks.UpdateTaskRequest.expires_at:type_name -> google.protobuf.Timestamp
+	5,  // 6: tasks.BatchPatchTasksRequest.updates:type_name -> tasks.TaskChanges
+	1,  // 7: tasks.BatchCreateTasksRequest.tasks:type_name -> tasks.CreateTaskRequest
+	16, // 8: tasks.SyncTask.created_at:type_name -> google.protobuf.Timestamp
+	16, // 9: tasks.SyncTask.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 10: tasks.SyncTask.completed_at:type_name -> google.protobuf.Timestamp
+	16, // 11: tasks.SyncTask.expires_at:type_name -> google.protobuf.Timestamp
+	8,  // 12: tasks.SyncTasksRequest.tasks:type_name -> tasks.SyncTask
+	16, // 13: tasks.SeedTask.expires_at:type_name -> google.protobuf.Timestamp
+	10, // 14: tasks.SeedTasksRequest.tasks:type_name -> tasks.SeedTask
+	15, // 15: tasks.LookupTasksResponse.found:type_name -> tasks.LookupTasksResponse.FoundEntry
+	0,  // 16: tasks.GetTaskResponse.task:type_name -> tasks.Task
+	0,  // 17: tasks.ListTasksResponse.tasks:type_name -> tasks.Task
+	0,  // 18: tasks.LookupTasksResponse.FoundEntry.value:type_name -> tasks.Task
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_v1_tasks_proto_init() }
@@ -374,14 +1144,17 @@ func file_api_proto_v1_tasks_proto_init() {
 	if File_api_proto_v1_tasks_proto != nil {
 		return
 	}
+	file_api_proto_v1_tasks_proto_msgTypes[0].OneofWrappers = []any{}
 	file_api_proto_v1_tasks_proto_msgTypes[2].OneofWrappers = []any{}
+	file_api_proto_v1_tasks_proto_msgTypes[5].OneofWrappers = []any{}
+	file_api_proto_v1_tasks_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_v1_tasks_proto_rawDesc), len(file_api_proto_v1_tasks_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
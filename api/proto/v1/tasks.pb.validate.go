@@ -35,6 +35,9 @@ var (
 	_ = sort.Sort
 )
 
+// define the regex for a UUID once up-front
+var _tasks_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
 // Validate checks the field values on Task with the rules defined in the proto
 // definition for this message. If any rules are violated, the first error
 // encountered is returned, or nil if there are no violations.
@@ -122,6 +125,70 @@ func (m *Task) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for Archived
+
+	if all {
+		switch v := interface{}(m.GetCompletedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TaskValidationError{
+					field:  "CompletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TaskValidationError{
+					field:  "CompletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCompletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TaskValidationError{
+				field:  "CompletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TaskValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TaskValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TaskValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.AssigneeId != nil {
+		// no validation rules for AssigneeId
+	}
+
 	if len(errors) > 0 {
 		return TaskMultiError(errors)
 	}
@@ -221,10 +288,10 @@ func (m *CreateTaskRequest) validate(all bool) error {
 
 	var errors []error
 
-	if l := utf8.RuneCountInString(m.GetTitle()); l < 1 || l > 100 {
+	if utf8.RuneCountInString(m.GetTitle()) < 1 {
 		err := CreateTaskRequestValidationError{
 			field:  "Title",
-			reason: "value length must be between 1 and 100 runes, inclusive",
+			reason: "value length must be at least 1 runes",
 		}
 		if !all {
 			return err
@@ -232,10 +299,41 @@ func (m *CreateTaskRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if utf8.RuneCountInString(m.GetDescription()) > 500 {
+	// no validation rules for Description
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateTaskRequestValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateTaskRequestValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateTaskRequestValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetBlockedBy()) > 20 {
 		err := CreateTaskRequestValidationError{
-			field:  "Description",
-			reason: "value length must be at most 500 runes",
+			field:  "BlockedBy",
+			reason: "value must contain no more than 20 item(s)",
 		}
 		if !all {
 			return err
@@ -243,6 +341,38 @@ func (m *CreateTaskRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	_CreateTaskRequest_BlockedBy_Unique := make(map[string]struct{}, len(m.GetBlockedBy()))
+
+	for idx, item := range m.GetBlockedBy() {
+		_, _ = idx, item
+
+		if _, exists := _CreateTaskRequest_BlockedBy_Unique[item]; exists {
+			err := CreateTaskRequestValidationError{
+				field:  fmt.Sprintf("BlockedBy[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_CreateTaskRequest_BlockedBy_Unique[item] = struct{}{}
+		}
+
+		if err := m._validateUuid(item); err != nil {
+			err = CreateTaskRequestValidationError{
+				field:  fmt.Sprintf("BlockedBy[%v]", idx),
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return CreateTaskRequestMultiError(errors)
 	}
@@ -250,6 +380,14 @@ func (m *CreateTaskRequest) validate(all bool) error {
 	return nil
 }
 
+func (m *CreateTaskRequest) _validateUuid(uuid string) error {
+	if matched := _tasks_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
 // CreateTaskRequestMultiError is an error wrapping multiple validation errors
 // returned by CreateTaskRequest.ValidateAll() if the designated constraints
 // aren't met.
@@ -345,10 +483,10 @@ func (m *UpdateTaskRequest) validate(all bool) error {
 
 	var errors []error
 
-	if l := utf8.RuneCountInString(m.GetTitle()); l < 1 || l > 100 {
+	if utf8.RuneCountInString(m.GetTitle()) < 1 {
 		err := UpdateTaskRequestValidationError{
 			field:  "Title",
-			reason: "value length must be between 1 and 100 runes, inclusive",
+			reason: "value length must be at least 1 runes",
 		}
 		if !all {
 			return err
@@ -356,10 +494,39 @@ func (m *UpdateTaskRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if utf8.RuneCountInString(m.GetDescription()) > 500 {
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateTaskRequestValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateTaskRequestValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateTaskRequestValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetBlockedBy()) > 20 {
 		err := UpdateTaskRequestValidationError{
-			field:  "Description",
-			reason: "value length must be at most 500 runes",
+			field:  "BlockedBy",
+			reason: "value must contain no more than 20 item(s)",
 		}
 		if !all {
 			return err
@@ -367,6 +534,42 @@ func (m *UpdateTaskRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	_UpdateTaskRequest_BlockedBy_Unique := make(map[string]struct{}, len(m.GetBlockedBy()))
+
+	for idx, item := range m.GetBlockedBy() {
+		_, _ = idx, item
+
+		if _, exists := _UpdateTaskRequest_BlockedBy_Unique[item]; exists {
+			err := UpdateTaskRequestValidationError{
+				field:  fmt.Sprintf("BlockedBy[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_UpdateTaskRequest_BlockedBy_Unique[item] = struct{}{}
+		}
+
+		if err := m._validateUuid(item); err != nil {
+			err = UpdateTaskRequestValidationError{
+				field:  fmt.Sprintf("BlockedBy[%v]", idx),
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.Description != nil {
+		// no validation rules for Description
+	}
+
 	if m.Completed != nil {
 		// no validation rules for Completed
 	}
@@ -378,6 +581,14 @@ func (m *UpdateTaskRequest) validate(all bool) error {
 	return nil
 }
 
+func (m *UpdateTaskRequest) _validateUuid(uuid string) error {
+	if matched := _tasks_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
 // UpdateTaskRequestMultiError is an error wrapping multiple validation errors
 // returned by UpdateTaskRequest.ValidateAll() if the designated constraints
 // aren't met.
@@ -451,6 +662,1508 @@ var _ interface {
 	ErrorName() string
 } = UpdateTaskRequestValidationError{}
 
+// Validate checks the field values on AssignTaskRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *AssignTaskRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AssignTaskRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AssignTaskRequestMultiError, or nil if none found.
+func (m *AssignTaskRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AssignTaskRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetAssigneeId()); l < 1 || l > 64 {
+		err := AssignTaskRequestValidationError{
+			field:  "AssigneeId",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_AssignTaskRequest_AssigneeId_Pattern.MatchString(m.GetAssigneeId()) {
+		err := AssignTaskRequestValidationError{
+			field:  "AssigneeId",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9._@-]+$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return AssignTaskRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// AssignTaskRequestMultiError is an error wrapping multiple validation errors
+// returned by AssignTaskRequest.ValidateAll() if the designated constraints
+// aren't met.
+type AssignTaskRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AssignTaskRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AssignTaskRequestMultiError) AllErrors() []error { return m }
+
+// AssignTaskRequestValidationError is the validation error returned by
+// AssignTaskRequest.Validate if the designated constraints aren't met.
+type AssignTaskRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AssignTaskRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AssignTaskRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AssignTaskRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AssignTaskRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AssignTaskRequestValidationError) ErrorName() string {
+	return "AssignTaskRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AssignTaskRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAssignTaskRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AssignTaskRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AssignTaskRequestValidationError{}
+
+var _AssignTaskRequest_AssigneeId_Pattern = regexp.MustCompile("^[A-Za-z0-9._@-]+$")
+
+// Validate checks the field values on LookupTasksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *LookupTasksRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LookupTasksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// LookupTasksRequestMultiError, or nil if none found.
+func (m *LookupTasksRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LookupTasksRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetIds()); l < 1 || l > 100 {
+		err := LookupTasksRequestValidationError{
+			field:  "Ids",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_LookupTasksRequest_Ids_Unique := make(map[string]struct{}, len(m.GetIds()))
+
+	for idx, item := range m.GetIds() {
+		_, _ = idx, item
+
+		if _, exists := _LookupTasksRequest_Ids_Unique[item]; exists {
+			err := LookupTasksRequestValidationError{
+				field:  fmt.Sprintf("Ids[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_LookupTasksRequest_Ids_Unique[item] = struct{}{}
+		}
+
+		if err := m._validateUuid(item); err != nil {
+			err = LookupTasksRequestValidationError{
+				field:  fmt.Sprintf("Ids[%v]", idx),
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return LookupTasksRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *LookupTasksRequest) _validateUuid(uuid string) error {
+	if matched := _tasks_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// LookupTasksRequestMultiError is an error wrapping multiple validation errors
+// returned by LookupTasksRequest.ValidateAll() if the designated constraints
+// aren't met.
+type LookupTasksRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LookupTasksRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LookupTasksRequestMultiError) AllErrors() []error { return m }
+
+// LookupTasksRequestValidationError is the validation error returned by
+// LookupTasksRequest.Validate if the designated constraints aren't met.
+type LookupTasksRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LookupTasksRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LookupTasksRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LookupTasksRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LookupTasksRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LookupTasksRequestValidationError) ErrorName() string {
+	return "LookupTasksRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e LookupTasksRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLookupTasksRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LookupTasksRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LookupTasksRequestValidationError{}
+
+// Validate checks the field values on TaskChanges with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TaskChanges) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TaskChanges with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TaskChangesMultiError, or
+// nil if none found.
+func (m *TaskChanges) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TaskChanges) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = TaskChangesValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.Title != nil {
+
+		if utf8.RuneCountInString(m.GetTitle()) < 1 {
+			err := TaskChangesValidationError{
+				field:  "Title",
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.Description != nil {
+		// no validation rules for Description
+	}
+
+	if m.Completed != nil {
+		// no validation rules for Completed
+	}
+
+	if len(errors) > 0 {
+		return TaskChangesMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *TaskChanges) _validateUuid(uuid string) error {
+	if matched := _tasks_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// TaskChangesMultiError is an error wrapping multiple validation errors
+// returned by TaskChanges.ValidateAll() if the designated constraints aren't met.
+type TaskChangesMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TaskChangesMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TaskChangesMultiError) AllErrors() []error { return m }
+
+// TaskChangesValidationError is the validation error returned by
+// TaskChanges.Validate if the designated constraints aren't met.
+type TaskChangesValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TaskChangesValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TaskChangesValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TaskChangesValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TaskChangesValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TaskChangesValidationError) ErrorName() string { return "TaskChangesValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TaskChangesValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTaskChanges.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TaskChangesValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TaskChangesValidationError{}
+
+// Validate checks the field values on BatchPatchTasksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchPatchTasksRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchPatchTasksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchPatchTasksRequestMultiError, or nil if none found.
+func (m *BatchPatchTasksRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchPatchTasksRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetUpdates()); l < 1 || l > 100 {
+		err := BatchPatchTasksRequestValidationError{
+			field:  "Updates",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetUpdates() {
+		_, _ = idx, item
+
+		// skipping validation for updates
+
+	}
+
+	// no validation rules for Atomic
+
+	if len(errors) > 0 {
+		return BatchPatchTasksRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchPatchTasksRequestMultiError is an error wrapping multiple validation
+// errors returned by BatchPatchTasksRequest.ValidateAll() if the designated
+// constraints aren't met.
+type BatchPatchTasksRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchPatchTasksRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchPatchTasksRequestMultiError) AllErrors() []error { return m }
+
+// BatchPatchTasksRequestValidationError is the validation error returned by
+// BatchPatchTasksRequest.Validate if the designated constraints aren't met.
+type BatchPatchTasksRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchPatchTasksRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchPatchTasksRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchPatchTasksRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchPatchTasksRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchPatchTasksRequestValidationError) ErrorName() string {
+	return "BatchPatchTasksRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchPatchTasksRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchPatchTasksRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchPatchTasksRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchPatchTasksRequestValidationError{}
+
+// Validate checks the field values on BatchCreateTasksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchCreateTasksRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchCreateTasksRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchCreateTasksRequestMultiError, or nil if none found.
+func (m *BatchCreateTasksRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchCreateTasksRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetTasks()); l < 1 || l > 100 {
+		err := BatchCreateTasksRequestValidationError{
+			field:  "Tasks",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetTasks() {
+		_, _ = idx, item
+
+		// skipping validation for tasks
+
+	}
+
+	// no validation rules for Atomic
+
+	if len(errors) > 0 {
+		return BatchCreateTasksRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchCreateTasksRequestMultiError is an error wrapping multiple validation
+// errors returned by BatchCreateTasksRequest.ValidateAll() if the designated
+// constraints aren't met.
+type BatchCreateTasksRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchCreateTasksRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchCreateTasksRequestMultiError) AllErrors() []error { return m }
+
+// BatchCreateTasksRequestValidationError is the validation error returned by
+// BatchCreateTasksRequest.Validate if the designated constraints aren't met.
+type BatchCreateTasksRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchCreateTasksRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchCreateTasksRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchCreateTasksRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchCreateTasksRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchCreateTasksRequestValidationError) ErrorName() string {
+	return "BatchCreateTasksRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchCreateTasksRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchCreateTasksRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchCreateTasksRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchCreateTasksRequestValidationError{}
+
+// Validate checks the field values on SyncTask with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SyncTask) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SyncTask with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SyncTaskMultiError, or nil
+// if none found.
+func (m *SyncTask) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SyncTask) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = SyncTaskValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetTitle()) < 1 {
+		err := SyncTaskValidationError{
+			field:  "Title",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Description
+
+	// no validation rules for Completed
+
+	if m.GetCreatedAt() == nil {
+		err := SyncTaskValidationError{
+			field:  "CreatedAt",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetUpdatedAt() == nil {
+		err := SyncTaskValidationError{
+			field:  "UpdatedAt",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetCompletedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SyncTaskValidationError{
+					field:  "CompletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SyncTaskValidationError{
+					field:  "CompletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCompletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SyncTaskValidationError{
+				field:  "CompletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SyncTaskValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SyncTaskValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SyncTaskValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetBlockedBy()) > 20 {
+		err := SyncTaskValidationError{
+			field:  "BlockedBy",
+			reason: "value must contain no more than 20 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_SyncTask_BlockedBy_Unique := make(map[string]struct{}, len(m.GetBlockedBy()))
+
+	for idx, item := range m.GetBlockedBy() {
+		_, _ = idx, item
+
+		if _, exists := _SyncTask_BlockedBy_Unique[item]; exists {
+			err := SyncTaskValidationError{
+				field:  fmt.Sprintf("BlockedBy[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_SyncTask_BlockedBy_Unique[item] = struct{}{}
+		}
+
+		if err := m._validateUuid(item); err != nil {
+			err = SyncTaskValidationError{
+				field:  fmt.Sprintf("BlockedBy[%v]", idx),
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.AssigneeId != nil {
+
+		if l := utf8.RuneCountInString(m.GetAssigneeId()); l < 1 || l > 64 {
+			err := SyncTaskValidationError{
+				field:  "AssigneeId",
+				reason: "value length must be between 1 and 64 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if !_SyncTask_AssigneeId_Pattern.MatchString(m.GetAssigneeId()) {
+			err := SyncTaskValidationError{
+				field:  "AssigneeId",
+				reason: "value does not match regex pattern \"^[A-Za-z0-9._@-]+$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return SyncTaskMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *SyncTask) _validateUuid(uuid string) error {
+	if matched := _tasks_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// SyncTaskMultiError is an error wrapping multiple validation errors returned
+// by SyncTask.ValidateAll() if the designated constraints aren't met.
+type SyncTaskMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SyncTaskMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SyncTaskMultiError) AllErrors() []error { return m }
+
+// SyncTaskValidationError is the validation error returned by
+// SyncTask.Validate if the designated constraints aren't met.
+type SyncTaskValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SyncTaskValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SyncTaskValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SyncTaskValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SyncTaskValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SyncTaskValidationError) ErrorName() string { return "SyncTaskValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SyncTaskValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSyncTask.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SyncTaskValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SyncTaskValidationError{}
+
+var _SyncTask_AssigneeId_Pattern = regexp.MustCompile("^[A-Za-z0-9._@-]+$")
+
+// Validate checks the field values on SyncTasksRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SyncTasksRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SyncTasksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SyncTasksRequestMultiError, or nil if none found.
+func (m *SyncTasksRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SyncTasksRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetTasks()); l < 1 || l > 100 {
+		err := SyncTasksRequestValidationError{
+			field:  "Tasks",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetTasks() {
+		_, _ = idx, item
+
+		// skipping validation for tasks
+
+	}
+
+	if len(errors) > 0 {
+		return SyncTasksRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SyncTasksRequestMultiError is an error wrapping multiple validation errors
+// returned by SyncTasksRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SyncTasksRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SyncTasksRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SyncTasksRequestMultiError) AllErrors() []error { return m }
+
+// SyncTasksRequestValidationError is the validation error returned by
+// SyncTasksRequest.Validate if the designated constraints aren't met.
+type SyncTasksRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SyncTasksRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SyncTasksRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SyncTasksRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SyncTasksRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SyncTasksRequestValidationError) ErrorName() string { return "SyncTasksRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SyncTasksRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSyncTasksRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SyncTasksRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SyncTasksRequestValidationError{}
+
+// Validate checks the field values on SeedTask with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SeedTask) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SeedTask with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SeedTaskMultiError, or nil
+// if none found.
+func (m *SeedTask) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SeedTask) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetTitle()) < 1 {
+		err := SeedTaskValidationError{
+			field:  "Title",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Description
+
+	if all {
+		switch v := interface{}(m.GetExpiresAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SeedTaskValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SeedTaskValidationError{
+					field:  "ExpiresAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiresAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SeedTaskValidationError{
+				field:  "ExpiresAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SeedTaskMultiError(errors)
+	}
+
+	return nil
+}
+
+// SeedTaskMultiError is an error wrapping multiple validation errors returned
+// by SeedTask.ValidateAll() if the designated constraints aren't met.
+type SeedTaskMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SeedTaskMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SeedTaskMultiError) AllErrors() []error { return m }
+
+// SeedTaskValidationError is the validation error returned by
+// SeedTask.Validate if the designated constraints aren't met.
+type SeedTaskValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SeedTaskValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SeedTaskValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SeedTaskValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SeedTaskValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SeedTaskValidationError) ErrorName() string { return "SeedTaskValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SeedTaskValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSeedTask.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SeedTaskValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SeedTaskValidationError{}
+
+// Validate checks the field values on SeedTasksRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SeedTasksRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SeedTasksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SeedTasksRequestMultiError, or nil if none found.
+func (m *SeedTasksRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SeedTasksRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetTasks()) > 1000 {
+		err := SeedTasksRequestValidationError{
+			field:  "Tasks",
+			reason: "value must contain no more than 1000 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetTasks() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SeedTasksRequestValidationError{
+						field:  fmt.Sprintf("Tasks[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SeedTasksRequestValidationError{
+						field:  fmt.Sprintf("Tasks[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SeedTasksRequestValidationError{
+					field:  fmt.Sprintf("Tasks[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return SeedTasksRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SeedTasksRequestMultiError is an error wrapping multiple validation errors
+// returned by SeedTasksRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SeedTasksRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SeedTasksRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SeedTasksRequestMultiError) AllErrors() []error { return m }
+
+// SeedTasksRequestValidationError is the validation error returned by
+// SeedTasksRequest.Validate if the designated constraints aren't met.
+type SeedTasksRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SeedTasksRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SeedTasksRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SeedTasksRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SeedTasksRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SeedTasksRequestValidationError) ErrorName() string { return "SeedTasksRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SeedTasksRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSeedTasksRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SeedTasksRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SeedTasksRequestValidationError{}
+
+// Validate checks the field values on LookupTasksResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *LookupTasksResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LookupTasksResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// LookupTasksResponseMultiError, or nil if none found.
+func (m *LookupTasksResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LookupTasksResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	{
+		sorted_keys := make([]string, len(m.GetFound()))
+		i := 0
+		for key := range m.GetFound() {
+			sorted_keys[i] = key
+			i++
+		}
+		sort.Slice(sorted_keys, func(i, j int) bool { return sorted_keys[i] < sorted_keys[j] })
+		for _, key := range sorted_keys {
+			val := m.GetFound()[key]
+			_ = val
+
+			// no validation rules for Found[key]
+
+			if all {
+				switch v := interface{}(val).(type) {
+				case interface{ ValidateAll() error }:
+					if err := v.ValidateAll(); err != nil {
+						errors = append(errors, LookupTasksResponseValidationError{
+							field:  fmt.Sprintf("Found[%v]", key),
+							reason: "embedded message failed validation",
+							cause:  err,
+						})
+					}
+				case interface{ Validate() error }:
+					if err := v.Validate(); err != nil {
+						errors = append(errors, LookupTasksResponseValidationError{
+							field:  fmt.Sprintf("Found[%v]", key),
+							reason: "embedded message failed validation",
+							cause:  err,
+						})
+					}
+				}
+			} else if v, ok := interface{}(val).(interface{ Validate() error }); ok {
+				if err := v.Validate(); err != nil {
+					return LookupTasksResponseValidationError{
+						field:  fmt.Sprintf("Found[%v]", key),
+						reason: "embedded message failed validation",
+						cause:  err,
+					}
+				}
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return LookupTasksResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// LookupTasksResponseMultiError is an error wrapping multiple validation
+// errors returned by LookupTasksResponse.ValidateAll() if the designated
+// constraints aren't met.
+type LookupTasksResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LookupTasksResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LookupTasksResponseMultiError) AllErrors() []error { return m }
+
+// LookupTasksResponseValidationError is the validation error returned by
+// LookupTasksResponse.Validate if the designated constraints aren't met.
+type LookupTasksResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LookupTasksResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LookupTasksResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LookupTasksResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LookupTasksResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LookupTasksResponseValidationError) ErrorName() string {
+	return "LookupTasksResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e LookupTasksResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLookupTasksResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LookupTasksResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LookupTasksResponseValidationError{}
+
 // Validate checks the field values on GetTaskResponse with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -2,20 +2,32 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
+	// Embeds the zone database, so ?tz= works on hosts without one
+	_ "time/tzdata"
 
+	"github.com/PinceredCoder/restGo/internal/config"
 	"github.com/PinceredCoder/restGo/internal/database"
 	"github.com/PinceredCoder/restGo/internal/handlers"
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/PinceredCoder/restGo/internal/server"
 	"github.com/lmittmann/tint"
 )
 
+// Build information, set with -ldflags "-X main.version=..." (see Makefile)
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildTime = "unknown"
+)
+
 func main() {
 	// Set up beautiful colored logging with tint
 	logger := slog.New(
@@ -26,50 +38,134 @@ func main() {
 	)
 	slog.SetDefault(logger)
 
-	logger.Info("Starting restGo API server")
+	logger.Info("Starting restGo API server", "version", version, "commit", commit)
 
-	r := chi.NewRouter()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
+	mongoConfig := func(uri string) database.MongoConfig {
+		return database.MongoConfig{
+			URI:                uri,
+			Database:           cfg.MongoDatabase,
+			Collection:         cfg.MongoCollection,
+			SlowQueryThreshold: cfg.SlowQueryThreshold,
+			WriteConcern:       cfg.MongoWriteConcern,
+			Journal:            cfg.MongoJournal,
+			ReadPreference:     cfg.MongoReadPreference,
+			CacheSize:          cfg.CacheSize,
+			CacheTTL:           cfg.CacheTTL,
+			MaxConcurrentOps:   cfg.MaxConcurrentDBOps,
+			RedactUsername:     cfg.RedactMongoUsername,
+		}
+	}
 
-	logger.Info("Connecting to MongoDB", "uri", "mongodb://127.0.0.1:27017", "database", "tasks")
-	db, err := database.NewMongoDatabase(context.Background(), "mongodb://127.0.0.1:27017", "tasks")
+	logger.Info("Connecting to MongoDB", "uri", database.RedactURI(cfg.MongoURI, cfg.RedactMongoUsername), "database", cfg.MongoDatabase, "collection", cfg.MongoCollection)
+	mongoDB, err := database.ConnectMongo(context.Background(), mongoConfig(cfg.MongoURI), cfg.MongoConnectWait, logger)
 	if err != nil {
 		logger.Error("Failed to connect to MongoDB", "error", err)
 		log.Fatalf("Failed to connect to DB: %v", err)
 	}
-	defer db.Disconnect(context.Background())
 	logger.Info("Successfully connected to MongoDB")
 
-	taskHandler := handlers.NewTaskHandler(db, logger)
+	var db database.Database = mongoDB
 
-	r.Route("/api/v1", func(r chi.Router) {
-		r.Route("/tasks", func(r chi.Router) {
-			r.Get("/", taskHandler.GetAll)
-			r.Post("/", taskHandler.Create)
-			r.Get("/{id}", taskHandler.GetByID)
-			r.Put("/{id}", taskHandler.Update)
-			r.Delete("/{id}", taskHandler.Delete)
+	if len(cfg.TenantShards) > 0 {
+		shards, err := database.ConnectShards(cfg.TenantShards, cfg.MongoURI, mongoDB, func(uri string) (database.Database, error) {
+			logger.Info("Connecting to tenant shard", "uri", database.RedactURI(uri, cfg.RedactMongoUsername))
+			return database.ConnectMongo(context.Background(), mongoConfig(uri), cfg.MongoConnectWait, logger)
 		})
-	})
+		if err != nil {
+			logger.Error("Failed to connect to tenant shard", "error", err)
+			log.Fatalf("Failed to connect to DB: %v", err)
+		}
 
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"status":"ok"}`))
-	})
+		// Tenants without a shard stay on the default database
+		db = database.NewShardedDatabase(shards, mongoDB)
+		logger.Info("Routing tasks by tenant", "shards", len(shards), "header", cfg.TenantHeader)
+	}
+	healthHandler := handlers.NewHealthHandler(db, logger)
+	router := server.NewRouter(db, logger, cfg, handlers.BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildTime: buildTime,
+	}, healthHandler)
+
+	logger.Info("Enabled HTTP methods", "methods", cfg.EnabledMethods)
 
-	port := ":8080"
-	fmt.Printf("Server starting on %s\n", port)
+	srv := server.NewHTTPServer(cfg, router)
+
+	fmt.Printf("Server starting on %s\n", srv.Addr)
 	fmt.Println("API endpoints:")
 	fmt.Println("  GET    /health")
+	fmt.Println("  GET    /ready")
+	fmt.Println("  GET    /metrics/cache")
+	fmt.Println("  GET    /version")
+	fmt.Println("  GET    /api/v1/capabilities")
+	fmt.Println("  POST   /api/v1/admin/seed")
+	fmt.Println("  POST   /api/v1/sync")
 	fmt.Println("  GET    /api/v1/tasks")
+	fmt.Println("  HEAD   /api/v1/tasks")
 	fmt.Println("  POST   /api/v1/tasks")
+	fmt.Println("  POST   /api/v1/tasks/lookup")
+	fmt.Println("  POST   /api/v1/tasks/batch")
+	fmt.Println("  PATCH  /api/v1/tasks/batch")
+	fmt.Println("  GET    /api/v1/tasks/export")
+	fmt.Println("  GET    /api/v1/tasks/schema")
+	fmt.Println("  GET    /api/v1/tasks/count-by")
+	fmt.Println("  GET    /api/v1/tasks/completion-trend")
+	fmt.Println("  GET    /api/v1/tasks/descriptor")
 	fmt.Println("  GET    /api/v1/tasks/{id}")
+	fmt.Println("  HEAD   /api/v1/tasks/{id}")
 	fmt.Println("  PUT    /api/v1/tasks/{id}")
+	fmt.Println("  PATCH  /api/v1/tasks/{id}")
 	fmt.Println("  DELETE /api/v1/tasks/{id}")
+	fmt.Println("  GET    /api/v1/tasks/{id}/exists")
+	fmt.Println("  GET    /api/v1/tasks/{id}/next")
+	fmt.Println("  GET    /api/v1/tasks/{id}/prev")
+	fmt.Println("  GET    /api/v1/tasks/{id}/blockers")
+	fmt.Println("  POST   /api/v1/tasks/{id}/assign")
+	fmt.Println("  POST   /api/v1/tasks/{id}/unassign")
+	fmt.Println("  POST   /api/v1/tasks/{id}/archive")
+	fmt.Println("  POST   /api/v1/tasks/{id}/unarchive")
+	fmt.Println("  POST   /api/v1/tasks/{id}/complete")
+	fmt.Println("  POST   /api/v1/tasks/{id}/reopen")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	if err := http.ListenAndServe(port, r); err != nil {
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-serveErr:
 		fmt.Printf("Error starting server: %s\n", err)
+	case <-ctx.Done():
+		stop()
+		shutdown(srv, db, healthHandler, cfg, logger)
+	}
+}
+
+// shutdown takes the instance out of rotation, waits ShutdownDrainDelay for
+// load balancers to notice, then stops the server and the database, giving
+// each up to ShutdownTimeout to let running work finish.
+func shutdown(srv *http.Server, db database.Database, health *handlers.HealthHandler, cfg *config.Config, logger *slog.Logger) {
+	logger.Info("Shutdown signal received, failing readiness", "drain_delay", cfg.ShutdownDrainDelay)
+	health.Drain()
+	time.Sleep(cfg.ShutdownDrainDelay)
+
+	logger.Info("Stopping HTTP server", "timeout", cfg.ShutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("HTTP server did not stop cleanly", "error", err)
+	}
+
+	logger.Info("Disconnecting from MongoDB")
+	if err := db.Disconnect(ctx); err != nil {
+		logger.Error("Failed to disconnect from MongoDB", "error", err)
 	}
+
+	logger.Info("Shutdown complete")
 }
@@ -0,0 +1,545 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SupportedMethods lists the HTTP methods the API registers routes for.
+var SupportedMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// SupportedFeatures lists the optional task API features FEATURES can turn
+// off: the batch endpoints, completion-trend, count-by, exists, export,
+// lookup, next/prev navigation and sync.
+var SupportedFeatures = []string{"batch", "completion-trend", "count-by", "descriptor", "exists", "export", "lookup", "navigation", "sync"}
+
+// RequirableFields lists the task fields REQUIRED_FIELDS can make required.
+var RequirableFields = []string{"title", "description"}
+
+// SortableFields lists the task fields DEFAULT_SORT can order by.
+var SortableFields = []string{"createdAt", "updatedAt", "title"}
+
+// requestEncodings are the request Content-Encodings the server can decode.
+var requestEncodings = []string{"gzip", "deflate"}
+
+// readPreferences are the MongoDB read preference modes, lowercased.
+var readPreferences = []string{"primary", "primarypreferred", "secondary", "secondarypreferred", "nearest"}
+
+type Config struct {
+	Port string
+	// Environment names the deployment; "production" disables the admin seed
+	// endpoint whatever AllowSeed says
+	Environment string
+	AllowSeed   bool
+	// AdminToken is the bearer token the admin endpoints require
+	AdminToken      string
+	MongoURI        string
+	MongoDatabase   string
+	MongoCollection string
+	// MongoWriteConcern is "majority" or a node count; empty keeps the URI default
+	MongoWriteConcern   string
+	MongoJournal        bool
+	MongoReadPreference string
+	// RedactMongoUsername hides the username, not just the password, when a
+	// MongoDB URI is logged
+	RedactMongoUsername bool
+	// MongoConnectWait is how long startup keeps retrying an unreachable MongoDB
+	MongoConnectWait time.Duration
+	// TenantShards maps tenants to the MongoDB URI of their shard; tenants not
+	// listed, and requests without TenantHeader, use MongoURI
+	TenantShards map[string]string
+	TenantHeader string
+	// MaxConcurrentDBOps is read from MAX_CONCURRENT_DB_OPS; zero disables the cap
+	MaxConcurrentDBOps int
+	// CacheSize is read from CACHE_SIZE; zero disables the task cache
+	CacheSize int
+	CacheTTL  time.Duration
+	// SlowQueryThreshold is read from SLOW_QUERY_MS; zero disables slow query logging
+	SlowQueryThreshold time.Duration
+	RequestTimeout     time.Duration
+	// QuietRoutes are route patterns whose requests are logged at Debug
+	// rather than Info
+	QuietRoutes []string
+	// DeprecatedRoutes maps "METHOD /route/pattern" to the route's sunset
+	// date, the zero time when it has none
+	DeprecatedRoutes map[string]time.Time
+	// EnabledMethods restricts which task API routes are served; others get 405
+	EnabledMethods []string
+	// StrictAccept answers 406 to API requests that do not accept JSON
+	StrictAccept bool
+	// Features are the optional endpoints to serve; the others are not routed
+	Features []string
+	// DefaultCompletedFilter is "all", "open" or "done"
+	DefaultCompletedFilter string
+	MaxTitleLen            int
+	MaxDescriptionLen      int
+	// DiscardUnknownFields ignores unknown request body members rather than
+	// rejecting them
+	DiscardUnknownFields bool
+	// EmitUnsetFields lists unset task fields in responses, as null or their
+	// zero value, rather than leaving them out
+	EmitUnsetFields bool
+	// RequiredFields must not be blank on create and update; the title is
+	// required regardless, but may be blank unless listed
+	RequiredFields []string
+	// MaxResults caps the task list response; zero disables the cap
+	MaxResults int
+	// DefaultSort orders task lists without ?sort=, in the same syntax
+	DefaultSort string
+	// MaxResponseBytes caps the encoded size of a task list page; zero
+	// disables the cap
+	MaxResponseBytes int
+	// HTTP server limits; a zero timeout disables it
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	// ShutdownDrainDelay is how long /ready fails before the server stops
+	// accepting connections; ShutdownTimeout bounds the rest of the shutdown
+	ShutdownDrainDelay time.Duration
+	ShutdownTimeout    time.Duration
+	// RequestEncodings are the Content-Encodings accepted on request bodies;
+	// MaxDecompressedBytes bounds what one decoded body may expand to
+	RequestEncodings     []string
+	MaxDecompressedBytes int
+}
+
+// DefaultCompleted translates DefaultCompletedFilter into a completed filter;
+// nil means no filtering.
+func (c *Config) DefaultCompleted() *bool {
+	var completed bool
+	switch c.DefaultCompletedFilter {
+	case "open":
+		completed = false
+	case "done":
+		completed = true
+	default:
+		return nil
+	}
+	return &completed
+}
+
+// SeedEnabled reports whether POST /api/v1/admin/seed may run.
+func (c *Config) SeedEnabled() bool {
+	return c.AllowSeed && c.Environment != "production"
+}
+
+func (c *Config) MethodEnabled(method string) bool {
+	return slices.Contains(c.EnabledMethods, method)
+}
+
+// FeatureEnabled reports whether the optional feature is served.
+func (c *Config) FeatureEnabled(feature string) bool {
+	return slices.Contains(c.Features, feature)
+}
+
+// Load reads the configuration from environment variables, falling back to
+// defaults suitable for local development.
+func Load() (*Config, error) {
+	cfg := &Config{
+		Port:            getEnv("PORT", "8080"),
+		Environment:     getEnv("APP_ENV", "development"),
+		AdminToken:      getEnv("ADMIN_TOKEN", ""),
+		MongoURI:        getEnv("MONGO_URI", "mongodb://127.0.0.1:27017"),
+		MongoDatabase:   getEnv("MONGO_DATABASE", "tasks"),
+		MongoCollection: getEnv("MONGO_COLLECTION", "tasks"),
+
+		MongoWriteConcern:   getEnv("MONGO_WRITE_CONCERN", ""),
+		MongoReadPreference: getEnv("MONGO_READ_PREFERENCE", ""),
+		TenantHeader:        getEnv("TENANT_HEADER", "X-Tenant-ID"),
+
+		DefaultCompletedFilter: getEnv("DEFAULT_COMPLETED_FILTER", "all"),
+	}
+
+	var err error
+	if cfg.MongoJournal, err = getBool("MONGO_JOURNAL", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.AllowSeed, err = getBool("ALLOW_SEED", false); err != nil {
+		return nil, err
+	}
+	if cfg.AllowSeed && cfg.AdminToken == "" {
+		return nil, fmt.Errorf("invalid ALLOW_SEED: seeding requires ADMIN_TOKEN")
+	}
+
+	if cfg.RedactMongoUsername, err = getBool("REDACT_MONGO_USERNAME", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.MongoConnectWait, err = getDuration("MONGO_CONNECT_WAIT", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.MongoWriteConcern != "" && cfg.MongoWriteConcern != "majority" {
+		if n, err := strconv.Atoi(cfg.MongoWriteConcern); err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid MONGO_WRITE_CONCERN %q: must be majority or a number of nodes", cfg.MongoWriteConcern)
+		}
+	}
+
+	if cfg.MongoReadPreference != "" && !slices.Contains(readPreferences, strings.ToLower(cfg.MongoReadPreference)) {
+		return nil, fmt.Errorf("invalid MONGO_READ_PREFERENCE %q: must be primary, primaryPreferred, secondary, secondaryPreferred or nearest", cfg.MongoReadPreference)
+	}
+
+	if cfg.SlowQueryThreshold, err = getMilliseconds("SLOW_QUERY_MS", 500*time.Millisecond); err != nil {
+		return nil, err
+	}
+
+	if cfg.TenantShards, err = getShards("TENANT_SHARDS"); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxConcurrentDBOps, err = getInt("MAX_CONCURRENT_DB_OPS", 0); err != nil {
+		return nil, err
+	}
+	if cfg.MaxConcurrentDBOps < 0 {
+		return nil, fmt.Errorf("invalid MAX_CONCURRENT_DB_OPS %d: must not be negative", cfg.MaxConcurrentDBOps)
+	}
+
+	if cfg.CacheSize, err = getInt("CACHE_SIZE", 0); err != nil {
+		return nil, err
+	}
+
+	if cfg.CacheTTL, err = getDuration("CACHE_TTL", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.RequestTimeout, err = getDuration("REQUEST_TIMEOUT", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	serverTimeouts := []struct {
+		key      string
+		target   *time.Duration
+		fallback time.Duration
+	}{
+		{"READ_HEADER_TIMEOUT", &cfg.ReadHeaderTimeout, 5 * time.Second},
+		{"READ_TIMEOUT", &cfg.ReadTimeout, 30 * time.Second},
+		{"WRITE_TIMEOUT", &cfg.WriteTimeout, 90 * time.Second},
+		{"IDLE_TIMEOUT", &cfg.IdleTimeout, 120 * time.Second},
+	}
+	for _, timeout := range serverTimeouts {
+		if *timeout.target, err = getDuration(timeout.key, timeout.fallback); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ShutdownDrainDelay, err = getDuration("SHUTDOWN_DRAIN_DELAY", 0); err != nil {
+		return nil, err
+	}
+
+	if cfg.ShutdownTimeout, err = getDuration("SHUTDOWN_TIMEOUT", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxHeaderBytes, err = getInt("MAX_HEADER_BYTES", 64<<10); err != nil {
+		return nil, err
+	}
+	if cfg.MaxHeaderBytes < 1 {
+		return nil, fmt.Errorf("invalid MAX_HEADER_BYTES %d: must be positive", cfg.MaxHeaderBytes)
+	}
+
+	cfg.QuietRoutes = getList("QUIET_ROUTES")
+	if _, ok := os.LookupEnv("QUIET_ROUTES"); !ok {
+		cfg.QuietRoutes = []string{"/health", "/ready", "/metrics/cache"}
+	}
+
+	if cfg.DeprecatedRoutes, err = getDeprecatedRoutes("DEPRECATED_ROUTES"); err != nil {
+		return nil, err
+	}
+
+	if cfg.EnabledMethods, err = getMethods("ENABLED_METHODS"); err != nil {
+		return nil, err
+	}
+
+	if cfg.Features, err = getFeatures("FEATURES"); err != nil {
+		return nil, err
+	}
+
+	if cfg.StrictAccept, err = getBool("STRICT_ACCEPT", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxTitleLen, err = getInt("MAX_TITLE_LEN", 100); err != nil {
+		return nil, err
+	}
+	if cfg.MaxTitleLen < 1 {
+		return nil, fmt.Errorf("invalid MAX_TITLE_LEN %d: titles need at least 1 character", cfg.MaxTitleLen)
+	}
+
+	if cfg.MaxDescriptionLen, err = getInt("MAX_DESCRIPTION_LEN", 500); err != nil {
+		return nil, err
+	}
+
+	if cfg.RequiredFields, err = getRequiredFields("REQUIRED_FIELDS"); err != nil {
+		return nil, err
+	}
+
+	if cfg.DiscardUnknownFields, err = getBool("DISCARD_UNKNOWN_FIELDS", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.EmitUnsetFields, err = getBool("EMIT_UNSET_FIELDS", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxResults, err = getInt("MAX_RESULTS", 0); err != nil {
+		return nil, err
+	}
+	if cfg.MaxResults < 0 {
+		return nil, fmt.Errorf("invalid MAX_RESULTS %d: must not be negative", cfg.MaxResults)
+	}
+
+	if cfg.DefaultSort, err = getSort("DEFAULT_SORT", "createdAt"); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxResponseBytes, err = getInt("MAX_RESPONSE_BYTES", 0); err != nil {
+		return nil, err
+	}
+	if cfg.MaxResponseBytes < 0 {
+		return nil, fmt.Errorf("invalid MAX_RESPONSE_BYTES %d: must not be negative", cfg.MaxResponseBytes)
+	}
+
+	if cfg.RequestEncodings, err = getEncodings("REQUEST_ENCODINGS", []string{"gzip"}); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxDecompressedBytes, err = getInt("MAX_DECOMPRESSED_BYTES", 1<<20); err != nil {
+		return nil, err
+	}
+	if cfg.MaxDecompressedBytes < 1 {
+		return nil, fmt.Errorf("invalid MAX_DECOMPRESSED_BYTES %d: must be positive", cfg.MaxDecompressedBytes)
+	}
+
+	switch cfg.DefaultCompletedFilter {
+	case "all", "open", "done":
+	default:
+		return nil, fmt.Errorf("invalid DEFAULT_COMPLETED_FILTER %q: must be all, open or done", cfg.DefaultCompletedFilter)
+	}
+
+	return cfg, nil
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
+
+func getInt(key string, fallback int) (int, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative integer", key, value)
+	}
+	return n, nil
+}
+
+func getBool(key string, fallback bool) (bool, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be true or false", key, value)
+	}
+	return b, nil
+}
+
+func getMilliseconds(key string, fallback time.Duration) (time.Duration, error) {
+	ms, err := getInt(key, int(fallback/time.Millisecond))
+	return time.Duration(ms) * time.Millisecond, err
+}
+
+func getDuration(key string, fallback time.Duration) (time.Duration, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative duration like 30s", key, value)
+	}
+	return d, nil
+}
+
+func getList(key string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getMethods reads a list of HTTP methods. Like FEATURES, unset enables
+// them all and an empty value enables none.
+func getMethods(key string) ([]string, error) {
+	if _, ok := os.LookupEnv(key); !ok {
+		return slices.Clone(SupportedMethods), nil
+	}
+
+	methods := getList(key)
+	for i, method := range methods {
+		methods[i] = strings.ToUpper(method)
+		if !slices.Contains(SupportedMethods, methods[i]) {
+			return nil, fmt.Errorf("invalid %s: unsupported method %q", key, method)
+		}
+	}
+	return methods, nil
+}
+
+// getFeatures reads a list of optional features. Unset enables them all; an
+// empty value enables none.
+func getFeatures(key string) ([]string, error) {
+	if _, ok := os.LookupEnv(key); !ok {
+		return slices.Clone(SupportedFeatures), nil
+	}
+
+	features := getList(key)
+	for i, feature := range features {
+		features[i] = strings.ToLower(feature)
+		if !slices.Contains(SupportedFeatures, features[i]) {
+			return nil, fmt.Errorf("invalid %s: unknown feature %q", key, feature)
+		}
+	}
+	return features, nil
+}
+
+// getDeprecatedRoutes reads a list of "METHOD /route/pattern" entries, each
+// optionally followed by =YYYY-MM-DD, the date the route is to be removed.
+// Unset or empty deprecates none.
+func getDeprecatedRoutes(key string) (map[string]time.Time, error) {
+	entries := getList(key)
+	if entries == nil {
+		return nil, nil
+	}
+
+	routes := make(map[string]time.Time, len(entries))
+	for _, entry := range entries {
+		route, date, dated := strings.Cut(entry, "=")
+		method, pattern, found := strings.Cut(strings.TrimSpace(route), " ")
+		method, pattern = strings.ToUpper(method), strings.TrimSpace(pattern)
+		if !found || !strings.HasPrefix(pattern, "/") {
+			return nil, fmt.Errorf("invalid %s entry %q: must be METHOD /route/pattern", key, entry)
+		}
+		if method != http.MethodHead && !slices.Contains(SupportedMethods, method) {
+			return nil, fmt.Errorf("invalid %s: unsupported method %q", key, method)
+		}
+
+		var sunset time.Time
+		if dated {
+			var err error
+			if sunset, err = time.Parse(time.DateOnly, strings.TrimSpace(date)); err != nil {
+				return nil, fmt.Errorf("invalid %s entry %q: sunset must be a date like 2026-12-31", key, entry)
+			}
+		}
+
+		route = method + " " + pattern
+		if _, exists := routes[route]; exists {
+			return nil, fmt.Errorf("invalid %s: route %q listed twice", key, route)
+		}
+		routes[route] = sunset
+	}
+	return routes, nil
+}
+
+// getRequiredFields reads a list of task fields to require. Unset or empty
+// requires none beyond the proto rules.
+func getRequiredFields(key string) ([]string, error) {
+	fields := getList(key)
+	for i, field := range fields {
+		fields[i] = strings.ToLower(field)
+		if !slices.Contains(RequirableFields, fields[i]) {
+			return nil, fmt.Errorf("invalid %s: field %q cannot be required", key, field)
+		}
+	}
+	return fields, nil
+}
+
+// getSort reads a sort in the ?sort= syntax: comma-separated fields from
+// SortableFields, each at most once, prefixed with - to order descending.
+func getSort(key, fallback string) (string, error) {
+	value := getEnv(key, fallback)
+
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		field := strings.TrimPrefix(strings.TrimSpace(name), "-")
+		if !slices.Contains(SortableFields, field) || seen[field] {
+			return "", fmt.Errorf("invalid %s %q: must list fields from %s, each at most once", key, value, strings.Join(SortableFields, ", "))
+		}
+		seen[field] = true
+	}
+	return value, nil
+}
+
+// getEncodings reads a list of request encodings. Unlike getMethods, an empty
+// value is allowed and accepts only uncompressed bodies.
+func getEncodings(key string, fallback []string) ([]string, error) {
+	if _, ok := os.LookupEnv(key); !ok {
+		return fallback, nil
+	}
+
+	encodings := getList(key)
+	for i, encoding := range encodings {
+		encodings[i] = strings.ToLower(encoding)
+		if !slices.Contains(requestEncodings, encodings[i]) {
+			return nil, fmt.Errorf("invalid %s: unsupported encoding %q", key, encoding)
+		}
+	}
+	return encodings, nil
+}
+
+// getShards parses "tenant=uri" pairs separated by semicolons; commas are
+// left alone because replica set URIs contain them.
+func getShards(key string) (map[string]string, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	shards := make(map[string]string)
+	for _, pair := range strings.Split(value, ";") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		tenant, uri, found := strings.Cut(pair, "=")
+		tenant, uri = strings.TrimSpace(tenant), strings.TrimSpace(uri)
+		if !found || tenant == "" || uri == "" {
+			return nil, fmt.Errorf("invalid %s entry %q: must be tenant=uri", key, pair)
+		}
+		if _, exists := shards[tenant]; exists {
+			return nil, fmt.Errorf("invalid %s: tenant %q listed twice", key, tenant)
+		}
+		shards[tenant] = uri
+	}
+	return shards, nil
+}
@@ -0,0 +1,251 @@
+package config
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestLoadDefaults tests that an empty environment yields the defaults
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.Port != "8080" {
+		t.Errorf("expected port 8080, got %s", cfg.Port)
+	}
+
+	if !slices.Equal(cfg.EnabledMethods, SupportedMethods) {
+		t.Errorf("expected all methods enabled, got %v", cfg.EnabledMethods)
+	}
+
+	if !slices.Equal(cfg.Features, SupportedFeatures) {
+		t.Errorf("expected all features enabled, got %v", cfg.Features)
+	}
+
+	if !slices.Contains(cfg.QuietRoutes, "/health") {
+		t.Errorf("expected /health to be quiet by default, got %v", cfg.QuietRoutes)
+	}
+
+	if cfg.ReadHeaderTimeout != 5*time.Second {
+		t.Errorf("expected a 5s read header timeout, got %v", cfg.ReadHeaderTimeout)
+	}
+
+	if cfg.MaxResults != 0 {
+		t.Errorf("expected lists to be uncapped by default, got %d", cfg.MaxResults)
+	}
+}
+
+// TestLoadEnabledMethods tests parsing of ENABLED_METHODS
+func TestLoadEnabledMethods(t *testing.T) {
+	t.Setenv("ENABLED_METHODS", "get, Post")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if !cfg.MethodEnabled("GET") || !cfg.MethodEnabled("POST") {
+		t.Errorf("expected GET and POST enabled, got %v", cfg.EnabledMethods)
+	}
+
+	if cfg.MethodEnabled("DELETE") {
+		t.Error("expected DELETE to be disabled")
+	}
+
+	t.Setenv("ENABLED_METHODS", "")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(cfg.EnabledMethods) != 0 {
+		t.Errorf("expected no methods for an empty ENABLED_METHODS, got %v", cfg.EnabledMethods)
+	}
+}
+
+// TestLoadFeatures tests parsing of FEATURES
+func TestLoadFeatures(t *testing.T) {
+	t.Setenv("FEATURES", "Batch, export")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if !cfg.FeatureEnabled("batch") || !cfg.FeatureEnabled("export") {
+		t.Errorf("expected batch and export enabled, got %v", cfg.Features)
+	}
+	if cfg.FeatureEnabled("lookup") {
+		t.Error("expected lookup to be disabled")
+	}
+
+	t.Setenv("FEATURES", "")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(cfg.Features) != 0 {
+		t.Errorf("expected no features for an empty FEATURES, got %v", cfg.Features)
+	}
+}
+
+// TestLoadMongoSettings tests parsing of the write concern and read preference
+func TestLoadMongoSettings(t *testing.T) {
+	t.Setenv("MONGO_WRITE_CONCERN", "majority")
+	t.Setenv("MONGO_JOURNAL", "true")
+	t.Setenv("MONGO_READ_PREFERENCE", "secondaryPreferred")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.MongoWriteConcern != "majority" || !cfg.MongoJournal || cfg.MongoReadPreference != "secondaryPreferred" {
+		t.Errorf("unexpected Mongo settings: w=%q journal=%v read=%q",
+			cfg.MongoWriteConcern, cfg.MongoJournal, cfg.MongoReadPreference)
+	}
+}
+
+// TestLoadTenantShards tests parsing of TENANT_SHARDS, including replica set URIs
+func TestLoadTenantShards(t *testing.T) {
+	t.Setenv("TENANT_SHARDS", "acme=mongodb://a1:27017,a2:27017/?replicaSet=rs0; globex = mongodb://g:27017 ;")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	want := map[string]string{
+		"acme":   "mongodb://a1:27017,a2:27017/?replicaSet=rs0",
+		"globex": "mongodb://g:27017",
+	}
+	if !maps.Equal(cfg.TenantShards, want) {
+		t.Errorf("expected %v, got %v", want, cfg.TenantShards)
+	}
+
+	if cfg.TenantHeader != "X-Tenant-ID" {
+		t.Errorf("expected default tenant header, got %s", cfg.TenantHeader)
+	}
+}
+
+// TestLoadDeprecatedRoutes tests parsing of DEPRECATED_ROUTES, with and without a sunset date
+func TestLoadDeprecatedRoutes(t *testing.T) {
+	t.Setenv("DEPRECATED_ROUTES", "put /api/v1/tasks/{id}=2026-12-31, GET /api/v1/tasks/{id}/exists")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	want := map[string]time.Time{
+		"PUT /api/v1/tasks/{id}":        time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		"GET /api/v1/tasks/{id}/exists": {},
+	}
+	if !maps.Equal(cfg.DeprecatedRoutes, want) {
+		t.Errorf("expected %v, got %v", want, cfg.DeprecatedRoutes)
+	}
+}
+
+// TestLoadInvalid tests that malformed values are rejected
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{"ENABLED_METHODS", "GET,TRACE"},
+		{"FEATURES", "batch,webhooks"},
+		{"REQUIRED_FIELDS", "description,assignee"},
+		{"DISCARD_UNKNOWN_FIELDS", "lenient"},
+		{"EMIT_UNSET_FIELDS", "nulls"},
+		{"STRICT_ACCEPT", "sometimes"},
+		{"REQUEST_TIMEOUT", "soon"},
+		{"REQUEST_TIMEOUT", "-1s"},
+		{"DEFAULT_COMPLETED_FILTER", "pending"},
+		{"SLOW_QUERY_MS", "fast"},
+		{"MAX_TITLE_LEN", "0"},
+		{"MAX_DESCRIPTION_LEN", "-5"},
+		{"MAX_RESULTS", "-1"},
+		{"MAX_RESPONSE_BYTES", "-1"},
+		{"DEFAULT_SORT", "priority"},
+		{"DEFAULT_SORT", "-createdAt,createdAt"},
+		{"DEFAULT_SORT", ""},
+		{"MAX_RESPONSE_BYTES", "1MB"},
+		{"MAX_CONCURRENT_DB_OPS", "-1"},
+		{"MAX_CONCURRENT_DB_OPS", "many"},
+		{"ALLOW_SEED", "true"},
+		{"READ_HEADER_TIMEOUT", "-1s"},
+		{"WRITE_TIMEOUT", "slow"},
+		{"MAX_HEADER_BYTES", "0"},
+		{"SHUTDOWN_DRAIN_DELAY", "-5s"},
+		{"SHUTDOWN_TIMEOUT", "later"},
+		{"REQUEST_ENCODINGS", "gzip,br"},
+		{"MAX_DECOMPRESSED_BYTES", "0"},
+		{"MONGO_WRITE_CONCERN", "all"},
+		{"MONGO_WRITE_CONCERN", "-1"},
+		{"MONGO_JOURNAL", "sometimes"},
+		{"REDACT_MONGO_USERNAME", "maybe"},
+		{"MONGO_CONNECT_WAIT", "forever"},
+		{"MONGO_READ_PREFERENCE", "fastest"},
+		{"TENANT_SHARDS", "acme"},
+		{"TENANT_SHARDS", "acme=mongodb://a;acme=mongodb://b"},
+		{"DEPRECATED_ROUTES", "/api/v1/tasks/{id}"},
+		{"DEPRECATED_ROUTES", "TRACE /api/v1/tasks"},
+		{"DEPRECATED_ROUTES", "PUT /api/v1/tasks/{id}=soon"},
+		{"DEPRECATED_ROUTES", "PUT /api/v1/tasks/{id},put /api/v1/tasks/{id}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+
+			if _, err := Load(); err == nil {
+				t.Errorf("expected error for %s=%s", tt.key, tt.value)
+			}
+		})
+	}
+}
+
+// TestSeedEnabled tests that production never allows seeding
+func TestSeedEnabled(t *testing.T) {
+	tests := []struct {
+		environment string
+		allowSeed   bool
+		want        bool
+	}{
+		{"development", true, true},
+		{"development", false, false},
+		{"staging", true, true},
+		{"production", true, false},
+	}
+
+	for _, tt := range tests {
+		cfg := &Config{Environment: tt.environment, AllowSeed: tt.allowSeed}
+		if got := cfg.SeedEnabled(); got != tt.want {
+			t.Errorf("%s with ALLOW_SEED=%v: expected %v, got %v", tt.environment, tt.allowSeed, tt.want, got)
+		}
+	}
+}
+
+// TestDefaultCompleted tests translating the default completed filter
+func TestDefaultCompleted(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   *bool
+	}{
+		{"all", nil},
+		{"open", new(bool)},
+		{"done", func() *bool { b := true; return &b }()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			cfg := &Config{DefaultCompletedFilter: tt.filter}
+			got := cfg.DefaultCompleted()
+
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package database
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CacheStats reports how well the FindByID cache is doing.
+type CacheStats struct {
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+	Entries int    `json:"entries"`
+}
+
+// CachingRepository decorates a TaskRepository with an in-memory LRU cache of
+// FindByID results. Entries expire after a TTL and are dropped on every write
+// to the same task, so reads never see a task older than the last write made
+// through this process. Writes by other processes are only picked up on expiry.
+type CachingRepository struct {
+	next TaskRepository
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[uuid.UUID]*list.Element
+	order   *list.List // front is most recently used
+	// generation is bumped by every write; a miss only fills the cache if no
+	// write happened while it was reading, so a racing update is never undone
+	generation uint64
+	hits       uint64
+	misses     uint64
+}
+
+type cacheEntry struct {
+	id        uuid.UUID
+	task      Task
+	expiresAt time.Time
+}
+
+// NewCachingRepository wraps repo with a cache of up to size tasks, each kept
+// for at most ttl.
+func NewCachingRepository(repo TaskRepository, size int, ttl time.Duration) *CachingRepository {
+	return &CachingRepository{
+		next:    repo,
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[uuid.UUID]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+func (r *CachingRepository) Stats() CacheStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return CacheStats{Hits: r.hits, Misses: r.misses, Entries: r.order.Len()}
+}
+
+func (r *CachingRepository) FindByID(ctx context.Context, id uuid.UUID) (*Task, error) {
+	r.mu.Lock()
+	if elem, ok := r.entries[id]; ok {
+		entry := elem.Value.(*cacheEntry)
+		if r.now().Before(entry.expiresAt) {
+			r.order.MoveToFront(elem)
+			r.hits++
+			// Callers modify the task they get back, so never hand out the cached one
+			task := entry.task
+			r.mu.Unlock()
+			return &task, nil
+		}
+		r.remove(elem)
+	}
+	r.misses++
+	generation := r.generation
+	r.mu.Unlock()
+
+	task, err := r.next.FindByID(ctx, id)
+	if err != nil || task == nil {
+		return task, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generation == generation {
+		r.store(task)
+	}
+	return task, nil
+}
+
+func (r *CachingRepository) Create(ctx context.Context, task *Task) error {
+	return r.next.Create(ctx, task)
+}
+
+func (r *CachingRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	return r.next.Exists(ctx, id)
+}
+
+func (r *CachingRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*Task, error) {
+	return r.next.FindByIDs(ctx, ids)
+}
+
+func (r *CachingRepository) FindAll(ctx context.Context, query TaskQuery) ([]*Task, error) {
+	return r.next.FindAll(ctx, query)
+}
+
+func (r *CachingRepository) Stream(ctx context.Context, query TaskQuery, fn func(*Task) error) error {
+	return r.next.Stream(ctx, query, fn)
+}
+
+func (r *CachingRepository) CountBy(ctx context.Context, field string, query TaskQuery) (map[string]int64, error) {
+	return r.next.CountBy(ctx, field, query)
+}
+
+func (r *CachingRepository) CountCompletedByDay(ctx context.Context, from, to int64, loc *time.Location) (map[string]int64, error) {
+	return r.next.CountCompletedByDay(ctx, from, to, loc)
+}
+
+func (r *CachingRepository) HealthCheck(ctx context.Context) error {
+	return r.next.HealthCheck(ctx)
+}
+
+func (r *CachingRepository) FindOneAndUpdate(ctx context.Context, id uuid.UUID, update TaskUpdate) (*Task, error) {
+	defer r.invalidate(id)
+	return r.next.FindOneAndUpdate(ctx, id, update)
+}
+
+func (r *CachingRepository) UpdateMany(ctx context.Context, updates map[uuid.UUID]TaskUpdate) ([]uuid.UUID, error) {
+	defer func() {
+		for id := range updates {
+			r.invalidate(id)
+		}
+	}()
+	return r.next.UpdateMany(ctx, updates)
+}
+
+func (r *CachingRepository) UpsertMany(ctx context.Context, tasks []*Task) (*UpsertResult, error) {
+	defer func() {
+		for _, task := range tasks {
+			r.invalidate(task.ID)
+		}
+	}()
+	return r.next.UpsertMany(ctx, tasks)
+}
+
+func (r *CachingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.invalidate(id)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *CachingRepository) CreateMany(ctx context.Context, tasks []*Task) error {
+	// A cached miss cannot exist for a new ID, but bump the generation anyway
+	// so a lookup racing the insert does not cache a stale answer
+	defer func() {
+		for _, task := range tasks {
+			r.invalidate(task.ID)
+		}
+	}()
+	return r.next.CreateMany(ctx, tasks)
+}
+
+// DeleteMany empties the whole cache: which tasks matched is not known here.
+func (r *CachingRepository) DeleteMany(ctx context.Context, query TaskQuery) (int64, error) {
+	defer r.invalidateAll()
+	return r.next.DeleteMany(ctx, query)
+}
+
+// invalidate runs after the write, whether or not it succeeded: a failed
+// write may still have been applied.
+func (r *CachingRepository) invalidate(id uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generation++
+	if elem, ok := r.entries[id]; ok {
+		r.remove(elem)
+	}
+}
+
+func (r *CachingRepository) invalidateAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generation++
+	clear(r.entries)
+	r.order.Init()
+}
+
+// store must be called with mu held.
+func (r *CachingRepository) store(task *Task) {
+	entry := &cacheEntry{id: task.ID, task: *task, expiresAt: r.now().Add(r.ttl)}
+	// Never serve a task from the cache after MongoDB may have expired it
+	if task.ExpiresAt != nil && task.ExpiresAt.Before(entry.expiresAt) {
+		entry.expiresAt = *task.ExpiresAt
+	}
+
+	if elem, ok := r.entries[task.ID]; ok {
+		elem.Value = entry
+		r.order.MoveToFront(elem)
+		return
+	}
+
+	r.entries[task.ID] = r.order.PushFront(entry)
+	if r.order.Len() > r.size {
+		r.remove(r.order.Back())
+	}
+}
+
+// remove must be called with mu held.
+func (r *CachingRepository) remove(elem *list.Element) {
+	r.order.Remove(elem)
+	delete(r.entries, elem.Value.(*cacheEntry).id)
+}
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// mapRepository implements TaskRepository over a map, counting FindByID calls;
+// only the methods the cache intercepts may be called
+type mapRepository struct {
+	TaskRepository
+	mu    sync.Mutex
+	tasks map[uuid.UUID]Task
+	reads int
+}
+
+func (r *mapRepository) FindByID(ctx context.Context, id uuid.UUID) (*Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reads++
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, nil
+	}
+	return &task, nil
+}
+
+func (r *mapRepository) FindOneAndUpdate(ctx context.Context, id uuid.UUID, update TaskUpdate) (*Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, nil
+	}
+	before := task
+	update.Apply(&task)
+	r.tasks[id] = task
+	return &before, nil
+}
+
+func (r *mapRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tasks, id)
+	return nil
+}
+
+func newMapRepository(ids ...uuid.UUID) *mapRepository {
+	repo := &mapRepository{tasks: make(map[uuid.UUID]Task)}
+	for _, id := range ids {
+		repo.tasks[id] = Task{ID: id, Title: "Cached"}
+	}
+	return repo
+}
+
+// TestCachingRepositoryHits tests that repeated reads are served from the cache
+func TestCachingRepositoryHits(t *testing.T) {
+	id := uuid.New()
+	inner := newMapRepository(id)
+	cache := NewCachingRepository(inner, 10, time.Minute)
+
+	for range 3 {
+		task, err := cache.FindByID(context.Background(), id)
+		if err != nil || task == nil || task.Title != "Cached" {
+			t.Fatalf("unexpected result %+v, %v", task, err)
+		}
+		// Callers may modify the task; the cached copy must not change
+		task.Title = "Modified"
+	}
+
+	if inner.reads != 1 {
+		t.Errorf("expected 1 repository read, got %d", inner.reads)
+	}
+
+	if stats := cache.Stats(); stats != (CacheStats{Hits: 2, Misses: 1, Entries: 1}) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+// TestCachingRepositoryInvalidation tests that writes drop the cached task
+func TestCachingRepositoryInvalidation(t *testing.T) {
+	id := uuid.New()
+	inner := newMapRepository(id)
+	cache := NewCachingRepository(inner, 10, time.Minute)
+
+	cache.FindByID(context.Background(), id)
+	title := "Renamed"
+	cache.FindOneAndUpdate(context.Background(), id, TaskUpdate{Title: &title, UpdatedAt: 1})
+
+	task, _ := cache.FindByID(context.Background(), id)
+	if task == nil || task.Title != "Renamed" {
+		t.Errorf("expected the updated task after FindOneAndUpdate, got %+v", task)
+	}
+
+	cache.Delete(context.Background(), id)
+
+	if task, _ := cache.FindByID(context.Background(), id); task != nil {
+		t.Errorf("expected no task after Delete, got %+v", task)
+	}
+}
+
+// TestCachingRepositoryExpiry tests that entries are refetched after the TTL
+func TestCachingRepositoryExpiry(t *testing.T) {
+	id := uuid.New()
+	inner := newMapRepository(id)
+	cache := NewCachingRepository(inner, 10, time.Minute)
+
+	now := time.Date(2025, 11, 13, 10, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.FindByID(context.Background(), id)
+	now = now.Add(2 * time.Minute)
+	cache.FindByID(context.Background(), id)
+
+	if inner.reads != 2 {
+		t.Errorf("expected an expired entry to be refetched, got %d reads", inner.reads)
+	}
+}
+
+// TestCachingRepositoryTaskExpiry tests that a task is not served from the
+// cache past its own expiry, even within the TTL
+func TestCachingRepositoryTaskExpiry(t *testing.T) {
+	id := uuid.New()
+	inner := newMapRepository()
+	now := time.Date(2025, 11, 13, 10, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(30 * time.Second)
+	inner.tasks[id] = Task{ID: id, Title: "Ephemeral", ExpiresAt: &expiresAt}
+
+	cache := NewCachingRepository(inner, 10, time.Hour)
+	cache.now = func() time.Time { return now }
+
+	cache.FindByID(context.Background(), id)
+	cache.FindByID(context.Background(), id)
+	now = now.Add(time.Minute)
+	cache.FindByID(context.Background(), id)
+
+	if inner.reads != 2 {
+		t.Errorf("expected a refetch once the task expired, got %d reads", inner.reads)
+	}
+}
+
+// TestCachingRepositoryEviction tests that the least recently used task is evicted
+func TestCachingRepositoryEviction(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	inner := newMapRepository(a, b, c)
+	cache := NewCachingRepository(inner, 2, time.Minute)
+
+	cache.FindByID(context.Background(), a)
+	cache.FindByID(context.Background(), b)
+	cache.FindByID(context.Background(), a) // b is now least recently used
+	cache.FindByID(context.Background(), c)
+
+	reads := inner.reads
+	cache.FindByID(context.Background(), a)
+	if inner.reads != reads {
+		t.Error("expected a to stay cached")
+	}
+
+	cache.FindByID(context.Background(), b)
+	if inner.reads != reads+1 {
+		t.Error("expected b to have been evicted")
+	}
+
+	if entries := cache.Stats().Entries; entries != 2 {
+		t.Errorf("expected 2 entries, got %d", entries)
+	}
+}
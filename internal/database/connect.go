@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	connectInitialBackoff = 500 * time.Millisecond
+	connectMaxBackoff     = 10 * time.Second
+	// connectAttemptTimeout bounds one attempt, so an unreachable server is
+	// retried instead of waiting out the driver's server selection timeout
+	connectAttemptTimeout = 10 * time.Second
+)
+
+// ConnectMongo connects like NewMongoDatabase, but keeps retrying with
+// exponential backoff until wait has passed, so the server can start before
+// MongoDB does. A zero wait makes a single attempt.
+func ConnectMongo(ctx context.Context, cfg MongoConfig, wait time.Duration, logger *slog.Logger) (*MongoDatabase, error) {
+	return retry(ctx, wait, connectInitialBackoff, connectMaxBackoff, logger, func(ctx context.Context) (*MongoDatabase, error) {
+		ctx, cancel := context.WithTimeout(ctx, connectAttemptTimeout)
+		defer cancel()
+		return NewMongoDatabase(ctx, cfg)
+	})
+}
+
+// retry calls attempt until it succeeds, ctx ends, or the next attempt would
+// start after wait has passed. It returns the last attempt's error.
+func retry[T any](ctx context.Context, wait, backoff, maxBackoff time.Duration, logger *slog.Logger, attempt func(context.Context) (T, error)) (T, error) {
+	deadline := time.Now().Add(wait)
+
+	for n := 1; ; n++ {
+		result, err := attempt(ctx)
+		if err == nil {
+			return result, nil
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			logger.Error("Giving up connecting to MongoDB", "attempts", n, "error", err)
+			return result, err
+		}
+
+		logger.Warn("MongoDB not reachable, retrying", "attempt", n, "retry_in", backoff, "error", err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return result, err
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestRetry tests that attempts repeat until success or until the wait runs out
+func TestRetry(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+	errDown := stderrors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		wait         time.Duration
+		failures     int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{"first attempt succeeds", time.Second, 0, 1, false},
+		{"succeeds after retries", time.Second, 3, 4, false},
+		{"zero wait tries once", 0, 3, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			result, err := retry(context.Background(), tt.wait, 5*time.Millisecond, 20*time.Millisecond, logger,
+				func(context.Context) (string, error) {
+					attempts++
+					if attempts <= tt.failures {
+						return "", errDown
+					}
+					return "connected", nil
+				})
+
+			if tt.wantErr {
+				if !stderrors.Is(err, errDown) {
+					t.Errorf("expected the last attempt's error, got %v", err)
+				}
+			} else if err != nil || result != "connected" {
+				t.Errorf("expected success, got %q, %v", result, err)
+			}
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, attempts)
+			}
+		})
+	}
+}
+
+// TestRetryGivesUp tests that retries stop once the wait has run out
+func TestRetryGivesUp(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+
+	start := time.Now()
+	attempts := 0
+	_, err := retry(context.Background(), 50*time.Millisecond, 5*time.Millisecond, 20*time.Millisecond, logger, func(context.Context) (int, error) {
+		attempts++
+		return 0, stderrors.New("connection refused")
+	})
+
+	if err == nil || attempts < 2 {
+		t.Errorf("expected several failed attempts, got %d attempts and error %v", attempts, err)
+	}
+	// No attempt starts after the wait, so the last one begins within it
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to give up shortly after 50ms, took %s", elapsed)
+	}
+}
+
+// TestRetryCanceled tests that a canceled context stops the retries
+func TestRetryCanceled(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	_, err := retry(ctx, time.Minute, 10*time.Millisecond, 10*time.Millisecond, logger, func(context.Context) (int, error) {
+		attempts++
+		cancel()
+		return 0, stderrors.New("connection refused")
+	})
+
+	if err == nil || attempts != 1 {
+		t.Errorf("expected one failed attempt, got %d attempts and error %v", attempts, err)
+	}
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
@@ -11,16 +12,187 @@ import (
 
 type Database interface {
 	Ping(ctx context.Context) error
+	// Disconnect releases the backend. It refuses new repository operations
+	// and waits, up to ctx's deadline, for those already running to finish.
 	Disconnect(ctx context.Context) error
 	GetTaskRepository() TaskRepository
 }
 
 type TaskRepository interface {
 	Create(ctx context.Context, task *Task) error
+	// CreateMany inserts every task atomically: either all of them are
+	// written or none is.
+	CreateMany(ctx context.Context, tasks []*Task) error
 	FindByID(ctx context.Context, id uuid.UUID) (*Task, error)
-	FindAll(ctx context.Context) ([]*Task, error)
-	Update(ctx context.Context, id uuid.UUID, task *Task) error
+	// Exists reports whether a task with id is stored, without loading it.
+	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+	// FindByIDs returns the tasks that exist among ids, in no particular order.
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*Task, error)
+	FindAll(ctx context.Context, query TaskQuery) ([]*Task, error)
+	// Stream calls fn for every task matching query without loading the whole
+	// result set, stopping at the first error fn returns. Unlike the other
+	// operations it is bounded only by ctx.
+	Stream(ctx context.Context, query TaskQuery, fn func(*Task) error) error
+	// FindOneAndUpdate applies update in one atomic step and returns the task
+	// as it was before, so update.Apply gives it as it is afterwards. It
+	// returns nil if there is no such task or it was updated after
+	// update.UnmodifiedSince.
+	FindOneAndUpdate(ctx context.Context, id uuid.UUID, update TaskUpdate) (*Task, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteMany deletes every task matching query and reports how many went.
+	DeleteMany(ctx context.Context, query TaskQuery) (int64, error)
+	// CountBy counts the tasks matching query per value of a groupable field
+	// (see GroupableFields), keyed by the value's string form.
+	CountBy(ctx context.Context, field string, query TaskQuery) (map[string]int64, error)
+	// CountCompletedByDay counts the tasks completed in [from, to) (unix
+	// seconds) per calendar day in loc, keyed by date as 2006-01-02. Days
+	// without completions are left out.
+	CountCompletedByDay(ctx context.Context, from, to int64, loc *time.Location) (map[string]int64, error)
+	// UpdateMany applies every update, keyed by task ID, atomically: either
+	// all of them are written or none is. Like FindOneAndUpdate it misses
+	// tasks that are gone or were updated after update.UnmodifiedSince; if
+	// any is missed, nothing is written and their IDs are returned.
+	UpdateMany(ctx context.Context, updates map[uuid.UUID]TaskUpdate) ([]uuid.UUID, error)
+	// UpsertMany writes every task, keyed by task.ID, in one round trip: new
+	// tasks are inserted and stored ones replaced, unless the stored copy was
+	// updated after task.UpdatedAt, which is left alone and reported as a
+	// conflict. Unlike CreateMany it is not atomic.
+	UpsertMany(ctx context.Context, tasks []*Task) (*UpsertResult, error)
+	// HealthCheck verifies the task collection itself is reachable and readable,
+	// catching permission and collection-level problems a client ping misses.
+	HealthCheck(ctx context.Context) error
+}
+
+// TaskQuery narrows the set of tasks returned by FindAll.
+// The zero value matches every task.
+type TaskQuery struct {
+	AssigneeID *string
+	Completed  *bool
+	// CreatedFrom and CreatedTo bound createdAt inclusively (unix seconds)
+	CreatedFrom *int64
+	CreatedTo   *int64
+	// UpdatedSince keeps tasks updated strictly after it (unix seconds)
+	UpdatedSince *int64
+	// Archived selects archived (true) or active (false) tasks; nil matches both
+	Archived *bool
+	// HasDescription selects tasks with (true) or without (false) a non-empty
+	// description; nil matches both
+	HasDescription *bool
+	// Conditions must all hold; they come from ParseFilter
+	Conditions []Condition
+	// After and Before keep only tasks strictly after or before a position
+	// in (createdAt, ID) order
+	After  *Position
+	Before *Position
+	// Sort orders the results, DefaultSort when empty; ties are broken by
+	// ID, in the direction of the last key
+	Sort []SortKey
+	// Offset skips that many tasks of the sorted results
+	Offset int
+	// Limit caps the number of tasks returned; zero returns every match
+	Limit int
+	// Fields, when set, are the only stored fields loaded besides _id; the
+	// others may be left zero
+	Fields []string
+}
+
+// TaskUpdate is a set of field changes for FindOneAndUpdate; nil fields are
+// left as stored.
+type TaskUpdate struct {
+	Title       *string
+	Description *string
+	// Completed sets the completed flag. Completing an open task stamps
+	// completedAt with UpdatedAt, completing a done one keeps it, and
+	// reopening clears it.
+	Completed *bool
+	// ExpiresAt replaces the expiry; the zero time clears it
+	ExpiresAt *time.Time
+	// BlockedBy replaces the blockers; an empty list clears them
+	BlockedBy *[]uuid.UUID
+	// AssigneeID replaces the assignee; "" removes it
+	AssigneeID *string
+	Archived   *bool
+	UpdatedAt  int64
+	// UnmodifiedSince, when set, only applies the update if the stored
+	// updatedAt is not after it (unix seconds)
+	UnmodifiedSince *int64
+}
+
+// UpsertResult reports what UpsertMany did with each task.
+type UpsertResult struct {
+	Inserted  []uuid.UUID
+	Updated   []uuid.UUID
+	Conflicts []uuid.UUID // stored copies newer than the one written
+}
+
+// Apply makes the changes to task in memory, as FindOneAndUpdate does in the
+// database.
+func (u TaskUpdate) Apply(task *Task) {
+	if u.Title != nil {
+		task.Title = *u.Title
+	}
+	if u.Description != nil {
+		task.Description = *u.Description
+	}
+	if u.Completed != nil {
+		switch {
+		case *u.Completed && !task.Completed:
+			completedAt := u.UpdatedAt
+			task.CompletedAt = &completedAt
+		case !*u.Completed:
+			task.CompletedAt = nil
+		}
+		task.Completed = *u.Completed
+	}
+	if u.ExpiresAt != nil {
+		task.ExpiresAt = nil
+		if !u.ExpiresAt.IsZero() {
+			expiresAt := *u.ExpiresAt
+			task.ExpiresAt = &expiresAt
+		}
+	}
+	if u.BlockedBy != nil {
+		task.BlockedBy = nil
+		if len(*u.BlockedBy) > 0 {
+			task.BlockedBy = slices.Clone(*u.BlockedBy)
+		}
+	}
+	if u.Archived != nil {
+		task.Archived = *u.Archived
+	}
+	if u.AssigneeID != nil {
+		task.AssigneeID = nil
+		if *u.AssigneeID != "" {
+			assigneeID := *u.AssigneeID
+			task.AssigneeID = &assigneeID
+		}
+	}
+	task.UpdatedAt = u.UpdatedAt
+}
+
+// DefaultSort is the order of a query without Sort: oldest first, so results
+// are stable across calls.
+var DefaultSort = []SortKey{{Field: "createdAt"}}
+
+// GroupableFields lists the stored fields CountBy accepts, each with the value
+// a task without the field counts under.
+var GroupableFields = map[string]any{
+	"completed":  false,
+	"archived":   false,
+	"assigneeId": "",
+}
+
+// Position is a task's place in (createdAt, ID) order.
+type Position struct {
+	CreatedAt int64
+	ID        uuid.UUID
+}
+
+// SortKey orders query results by one stored field.
+type SortKey struct {
+	// Field is the stored field name: createdAt, updatedAt or title
+	Field      string
+	Descending bool
 }
 
 type Task struct {
@@ -28,17 +200,44 @@ type Task struct {
 	Title       string    `bson:"title"`
 	Description string    `bson:"description"`
 	Completed   bool      `bson:"completed"`
+	AssigneeID  *string   `bson:"assigneeId,omitempty"`
+	Archived    bool      `bson:"archived"`
+	CompletedAt *int64    `bson:"completedAt,omitempty"` // set only while Completed
 	CreatedAt   int64     `bson:"createdAt"`
 	UpdatedAt   int64     `bson:"updatedAt"`
+	// ExpiresAt is stored as a BSON date, the only type a TTL index acts on
+	ExpiresAt *time.Time `bson:"expiresAt,omitempty"`
+	// BlockedBy are the tasks that must be completed before this one can be
+	BlockedBy []uuid.UUID `bson:"blockedBy,omitempty"`
 }
 
 func (t *Task) ToProto() *tasks.Task {
+	var completedAt *timestamppb.Timestamp
+	if t.CompletedAt != nil {
+		completedAt = timestamppb.New(time.Unix(*t.CompletedAt, 0))
+	}
+
+	var expiresAt *timestamppb.Timestamp
+	if t.ExpiresAt != nil {
+		expiresAt = timestamppb.New(*t.ExpiresAt)
+	}
+
+	var blockedBy []string
+	for _, id := range t.BlockedBy {
+		blockedBy = append(blockedBy, id.String())
+	}
+
 	return &tasks.Task{
 		Id:          t.ID.String(),
 		Title:       t.Title,
 		Description: t.Description,
 		Completed:   t.Completed,
+		AssigneeId:  t.AssigneeID,
+		Archived:    t.Archived,
 		CreatedAt:   timestamppb.New(time.Unix(t.CreatedAt, 0)),
 		UpdatedAt:   timestamppb.New(time.Unix(t.UpdatedAt, 0)),
+		CompletedAt: completedAt,
+		ExpiresAt:   expiresAt,
+		BlockedBy:   blockedBy,
 	}
 }
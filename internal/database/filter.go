@@ -0,0 +1,169 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Condition compares one stored field with a value. Conditions only come out
+// of ParseFilter, so Field, Op and the type of Value are always allowlisted.
+type Condition struct {
+	Field string
+	Op    string // a MongoDB comparison operator such as "$eq"
+	Value any    // bool, string or int64, matching the field
+}
+
+// Filter DSL limits; a filter is a query parameter, not a query language.
+const (
+	maxFilterLen   = 1024
+	maxFilterTerms = 10
+)
+
+type filterKind int
+
+const (
+	kindBool filterKind = iota
+	kindString
+	kindTime
+)
+
+type filterField struct {
+	stored string
+	kind   filterKind
+}
+
+// filterFields is the allowlist of fields a filter may name, by their API name.
+var filterFields = map[string]filterField{
+	"completed":   {"completed", kindBool},
+	"archived":    {"archived", kindBool},
+	"assignee":    {"assigneeId", kindString},
+	"title":       {"title", kindString},
+	"createdAt":   {"createdAt", kindTime},
+	"updatedAt":   {"updatedAt", kindTime},
+	"completedAt": {"completedAt", kindTime},
+}
+
+// filterOps maps the DSL operators to MongoDB's. Ordering only applies to times.
+var filterOps = map[string]string{
+	"eq":  "$eq",
+	"ne":  "$ne",
+	"gt":  "$gt",
+	"gte": "$gte",
+	"lt":  "$lt",
+	"lte": "$lte",
+}
+
+// filterStringPattern keeps string values to plain words, the same characters
+// assignee IDs allow plus spaces.
+var filterStringPattern = regexp.MustCompile(`^[A-Za-z0-9._@ -]{1,100}$`)
+
+// ParseFilter parses a comma-separated list of terms, all of which must hold:
+//
+//	field:value       field equals value
+//	field:op:value    op is eq, ne, gt, gte, lt or lte; the ordering
+//	                  operators only apply to createdAt, updatedAt and completedAt
+//
+// For example "completed:false,createdAt:gte:2025-11-13T00:00:00Z". Fields and
+// operators outside the allowlist are rejected, and values are parsed into
+// the field's type, so nothing from the input reaches MongoDB as an operator
+// or field name.
+func ParseFilter(filter string) ([]Condition, error) {
+	if len(filter) > maxFilterLen {
+		return nil, fmt.Errorf("filter is longer than %d characters", maxFilterLen)
+	}
+
+	terms := strings.Split(filter, ",")
+	if len(terms) > maxFilterTerms {
+		return nil, fmt.Errorf("filter has more than %d terms", maxFilterTerms)
+	}
+
+	conditions := make([]Condition, 0, len(terms))
+	for _, term := range terms {
+		condition, err := parseFilterTerm(strings.TrimSpace(term))
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+func parseFilterTerm(term string) (Condition, error) {
+	name, rest, found := strings.Cut(term, ":")
+	if !found || rest == "" {
+		return Condition{}, fmt.Errorf("filter term %q must be field:value or field:op:value", term)
+	}
+
+	field, ok := filterFields[name]
+	if !ok {
+		return Condition{}, fmt.Errorf("cannot filter on field %q", name)
+	}
+
+	// Times may contain colons themselves, so a prefix only counts as an
+	// operator when it is one
+	op, value := "$eq", rest
+	if prefix, remainder, found := strings.Cut(rest, ":"); found {
+		if mongoOp, ok := filterOps[prefix]; ok {
+			op, value = mongoOp, remainder
+		} else if _, isTime := parseFilterTime(rest); !isTime {
+			return Condition{}, fmt.Errorf("unknown filter operator %q", prefix)
+		}
+	}
+
+	if field.kind != kindTime && op != "$eq" && op != "$ne" {
+		return Condition{}, fmt.Errorf("field %q only supports eq and ne", name)
+	}
+
+	condition := Condition{Field: field.stored, Op: op}
+	switch field.kind {
+	case kindBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return Condition{}, fmt.Errorf("%s must be true or false", name)
+		}
+		condition.Value = b
+	case kindString:
+		if !filterStringPattern.MatchString(value) {
+			return Condition{}, fmt.Errorf("%s may only contain letters, digits, spaces and . _ @ -", name)
+		}
+		condition.Value = value
+	case kindTime:
+		seconds, ok := parseFilterTime(value)
+		if !ok {
+			return Condition{}, fmt.Errorf("%s must be a unix timestamp or an RFC 3339 time", name)
+		}
+		condition.Value = seconds
+	}
+	return condition, nil
+}
+
+func parseFilterTime(value string) (int64, bool) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return seconds, true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, false
+	}
+	return t.Unix(), true
+}
+
+// conditionFilter translates a condition for MongoDB. A field that may be
+// missing from older documents counts as holding its GroupableFields default,
+// as it does in CountBy.
+func conditionFilter(c Condition) bson.M {
+	if fallback, ok := GroupableFields[c.Field]; ok && c.Value == fallback {
+		switch c.Op {
+		case "$eq":
+			return bson.M{c.Field: bson.M{"$in": bson.A{c.Value, nil}}}
+		case "$ne":
+			return bson.M{c.Field: bson.M{"$nin": bson.A{c.Value, nil}}}
+		}
+	}
+	return bson.M{c.Field: bson.M{c.Op: c.Value}}
+}
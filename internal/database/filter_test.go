@@ -0,0 +1,97 @@
+package database
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestParseFilter tests parsing of valid filters into typed conditions
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   []Condition
+	}{
+		{"completed:false", []Condition{{"completed", "$eq", false}}},
+		{"completed:true,archived:ne:true", []Condition{{"completed", "$eq", true}, {"archived", "$ne", true}}},
+		{"assignee:alice@example.com", []Condition{{"assigneeId", "$eq", "alice@example.com"}}},
+		{"title:Weekly report", []Condition{{"title", "$eq", "Weekly report"}}},
+		{"createdAt:gte:1700000000, createdAt:lt:1800000000", []Condition{{"createdAt", "$gte", int64(1700000000)}, {"createdAt", "$lt", int64(1800000000)}}},
+		{"updatedAt:2025-11-13T10:00:00Z", []Condition{{"updatedAt", "$eq", int64(1763028000)}}},
+		{"completedAt:gt:2025-11-13T10:00:00Z", []Condition{{"completedAt", "$gt", int64(1763028000)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			got, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter() returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestParseFilterRejects tests that malformed and malicious filters never parse
+func TestParseFilterRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+	}{
+		{"empty", ""},
+		{"empty term", "completed:true,"},
+		{"no value", "completed:"},
+		{"no separator", "completed"},
+		{"unknown field", "priority:HIGH"},
+		{"stored field name", "assigneeId:alice"},
+		{"operator as field", "$where:1"},
+		{"javascript operator", "title:$where:sleep(1000)"},
+		{"nested field", "title.$ne:x"},
+		{"prototype field", "__proto__:x"},
+		{"raw operator", "completed:$ne:true"},
+		{"unknown operator", "createdAt:regex:1"},
+		{"ordering a bool", "completed:gt:false"},
+		{"ordering a string", "title:lt:m"},
+		{"JSON value", `completed:{"$ne":null}`},
+		{"bad bool", "completed:yes please"},
+		{"regex value", "title:.*"},
+		{"quote in value", `assignee:a";db.dropDatabase()`},
+		{"operator in value", "assignee:$gt"},
+		{"brace in value", "title:{$gt:\"\"}"},
+		{"bad time", "createdAt:gte:yesterday"},
+		{"too many terms", strings.Repeat("completed:true,", 10) + "completed:true"},
+		{"too long", "title:" + strings.Repeat("a", 1100)},
+		{"value too long", "title:" + strings.Repeat("a", 101)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := ParseFilter(tt.filter); err == nil {
+				t.Errorf("expected %q to be rejected, got %v", tt.filter, got)
+			}
+		})
+	}
+}
+
+// TestConditionFilter tests the MongoDB translation, including fields older documents lack
+func TestConditionFilter(t *testing.T) {
+	tests := []struct {
+		condition Condition
+		want      bson.M
+	}{
+		{Condition{"completed", "$eq", true}, bson.M{"completed": bson.M{"$eq": true}}},
+		{Condition{"archived", "$eq", false}, bson.M{"archived": bson.M{"$in": bson.A{false, nil}}}},
+		{Condition{"archived", "$ne", false}, bson.M{"archived": bson.M{"$nin": bson.A{false, nil}}}},
+		{Condition{"createdAt", "$gte", int64(100)}, bson.M{"createdAt": bson.M{"$gte": int64(100)}}},
+	}
+
+	for _, tt := range tests {
+		if got := conditionFilter(tt.condition); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: expected %v, got %v", tt.condition, tt.want, got)
+		}
+	}
+}
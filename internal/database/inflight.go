@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrDisconnecting is returned by operations started after Disconnect began.
+var ErrDisconnecting = errors.New("database is disconnecting")
+
+// inFlightRepository decorates a TaskRepository, counting the operations in
+// progress so the database can let them finish before disconnecting.
+type inFlightRepository struct {
+	next TaskRepository
+
+	mu      sync.Mutex
+	closing bool
+	ops     sync.WaitGroup
+}
+
+func newInFlightRepository(repo TaskRepository) *inFlightRepository {
+	return &inFlightRepository{next: repo}
+}
+
+// start registers an operation; the caller must call r.ops.Done when it ends.
+func (r *inFlightRepository) start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closing {
+		return ErrDisconnecting
+	}
+	r.ops.Add(1)
+	return nil
+}
+
+// drain refuses new operations and waits for the running ones to finish or
+// for ctx to end, whichever comes first.
+func (r *inFlightRepository) drain(ctx context.Context) error {
+	r.mu.Lock()
+	r.closing = true
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.ops.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *inFlightRepository) Create(ctx context.Context, task *Task) error {
+	if err := r.start(); err != nil {
+		return err
+	}
+	defer r.ops.Done()
+	return r.next.Create(ctx, task)
+}
+
+func (r *inFlightRepository) CreateMany(ctx context.Context, tasks []*Task) error {
+	if err := r.start(); err != nil {
+		return err
+	}
+	defer r.ops.Done()
+	return r.next.CreateMany(ctx, tasks)
+}
+
+func (r *inFlightRepository) FindByID(ctx context.Context, id uuid.UUID) (*Task, error) {
+	if err := r.start(); err != nil {
+		return nil, err
+	}
+	defer r.ops.Done()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *inFlightRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	if err := r.start(); err != nil {
+		return false, err
+	}
+	defer r.ops.Done()
+	return r.next.Exists(ctx, id)
+}
+
+func (r *inFlightRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*Task, error) {
+	if err := r.start(); err != nil {
+		return nil, err
+	}
+	defer r.ops.Done()
+	return r.next.FindByIDs(ctx, ids)
+}
+
+func (r *inFlightRepository) FindAll(ctx context.Context, query TaskQuery) ([]*Task, error) {
+	if err := r.start(); err != nil {
+		return nil, err
+	}
+	defer r.ops.Done()
+	return r.next.FindAll(ctx, query)
+}
+
+func (r *inFlightRepository) Stream(ctx context.Context, query TaskQuery, fn func(*Task) error) error {
+	if err := r.start(); err != nil {
+		return err
+	}
+	defer r.ops.Done()
+	return r.next.Stream(ctx, query, fn)
+}
+
+func (r *inFlightRepository) CountCompletedByDay(ctx context.Context, from, to int64, loc *time.Location) (map[string]int64, error) {
+	if err := r.start(); err != nil {
+		return nil, err
+	}
+	defer r.ops.Done()
+	return r.next.CountCompletedByDay(ctx, from, to, loc)
+}
+
+func (r *inFlightRepository) FindOneAndUpdate(ctx context.Context, id uuid.UUID, update TaskUpdate) (*Task, error) {
+	if err := r.start(); err != nil {
+		return nil, err
+	}
+	defer r.ops.Done()
+	return r.next.FindOneAndUpdate(ctx, id, update)
+}
+
+func (r *inFlightRepository) UpdateMany(ctx context.Context, updates map[uuid.UUID]TaskUpdate) ([]uuid.UUID, error) {
+	if err := r.start(); err != nil {
+		return nil, err
+	}
+	defer r.ops.Done()
+	return r.next.UpdateMany(ctx, updates)
+}
+
+func (r *inFlightRepository) UpsertMany(ctx context.Context, tasks []*Task) (*UpsertResult, error) {
+	if err := r.start(); err != nil {
+		return nil, err
+	}
+	defer r.ops.Done()
+	return r.next.UpsertMany(ctx, tasks)
+}
+
+func (r *inFlightRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.start(); err != nil {
+		return err
+	}
+	defer r.ops.Done()
+	return r.next.Delete(ctx, id)
+}
+
+func (r *inFlightRepository) DeleteMany(ctx context.Context, query TaskQuery) (int64, error) {
+	if err := r.start(); err != nil {
+		return 0, err
+	}
+	defer r.ops.Done()
+	return r.next.DeleteMany(ctx, query)
+}
+
+func (r *inFlightRepository) CountBy(ctx context.Context, field string, query TaskQuery) (map[string]int64, error) {
+	if err := r.start(); err != nil {
+		return nil, err
+	}
+	defer r.ops.Done()
+	return r.next.CountBy(ctx, field, query)
+}
+
+func (r *inFlightRepository) HealthCheck(ctx context.Context) error {
+	if err := r.start(); err != nil {
+		return err
+	}
+	defer r.ops.Done()
+	return r.next.HealthCheck(ctx)
+}
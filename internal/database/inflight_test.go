@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// blockingRepository implements TaskRepository; FindAll runs until release is closed
+type blockingRepository struct {
+	TaskRepository
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingRepository) FindAll(ctx context.Context, query TaskQuery) ([]*Task, error) {
+	close(r.started)
+	<-r.release
+	return nil, nil
+}
+
+func startBlockedFindAll(t *testing.T) (*inFlightRepository, *blockingRepository) {
+	t.Helper()
+	inner := &blockingRepository{started: make(chan struct{}), release: make(chan struct{})}
+	repo := newInFlightRepository(inner)
+
+	go repo.FindAll(context.Background(), TaskQuery{})
+	<-inner.started
+	return repo, inner
+}
+
+// TestInFlightDrainWaits tests that draining blocks until a running operation completes
+func TestInFlightDrainWaits(t *testing.T) {
+	repo, inner := startBlockedFindAll(t)
+
+	drained := make(chan error)
+	go func() { drained <- repo.drain(context.Background()) }()
+
+	select {
+	case err := <-drained:
+		t.Fatalf("drain returned %v while an operation was running", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(inner.release)
+
+	select {
+	case err := <-drained:
+		if err != nil {
+			t.Errorf("expected a clean drain, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("drain did not return after the operation finished")
+	}
+}
+
+// TestInFlightDrainDeadline tests that draining gives up when its context expires
+func TestInFlightDrainDeadline(t *testing.T) {
+	repo, inner := startBlockedFindAll(t)
+	defer close(inner.release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := repo.drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+// TestInFlightRefusesAfterDrain tests that no operation starts once draining has begun
+func TestInFlightRefusesAfterDrain(t *testing.T) {
+	repo := newInFlightRepository(&blockingRepository{})
+
+	if err := repo.drain(context.Background()); err != nil {
+		t.Fatalf("drain() returned error: %v", err)
+	}
+
+	if _, err := repo.FindAll(context.Background(), TaskQuery{}); !errors.Is(err, ErrDisconnecting) {
+		t.Errorf("expected ErrDisconnecting, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnavailable means the database could not take the operation in time;
+// retrying later may succeed.
+var ErrUnavailable = errors.New("database is busy")
+
+// maxSlotWait bounds how long an operation queues for a slot, so callers
+// without a deadline, such as background jobs, fail too.
+const maxSlotWait = 5 * time.Second
+
+// limitedRepository decorates a TaskRepository, letting at most a fixed
+// number of operations run at once. The others queue until a slot frees up,
+// their context ends or maxSlotWait passes, so a burst of expensive queries
+// cannot take every connection.
+type limitedRepository struct {
+	next  TaskRepository
+	slots chan struct{}
+	wait  time.Duration
+}
+
+// NewLimitedRepository wraps repo so that at most limit operations run at
+// once. A limit of zero or less returns repo unchanged.
+func NewLimitedRepository(repo TaskRepository, limit int) TaskRepository {
+	if limit <= 0 {
+		return repo
+	}
+	return &limitedRepository{
+		next:  repo,
+		slots: make(chan struct{}, limit),
+		wait:  maxSlotWait,
+	}
+}
+
+// acquire takes a slot; the caller must call r.release when done.
+func (r *limitedRepository) acquire(ctx context.Context) error {
+	timer := time.NewTimer(r.wait)
+	defer timer.Stop()
+
+	select {
+	case r.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%w: no free database slot within %v", ErrUnavailable, r.wait)
+	case <-ctx.Done():
+		return fmt.Errorf("%w: no free database slot: %w", ErrUnavailable, ctx.Err())
+	}
+}
+
+func (r *limitedRepository) release() {
+	<-r.slots
+}
+
+func (r *limitedRepository) Create(ctx context.Context, task *Task) error {
+	if err := r.acquire(ctx); err != nil {
+		return err
+	}
+	defer r.release()
+	return r.next.Create(ctx, task)
+}
+
+func (r *limitedRepository) CreateMany(ctx context.Context, tasks []*Task) error {
+	if err := r.acquire(ctx); err != nil {
+		return err
+	}
+	defer r.release()
+	return r.next.CreateMany(ctx, tasks)
+}
+
+func (r *limitedRepository) FindByID(ctx context.Context, id uuid.UUID) (*Task, error) {
+	if err := r.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer r.release()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *limitedRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	if err := r.acquire(ctx); err != nil {
+		return false, err
+	}
+	defer r.release()
+	return r.next.Exists(ctx, id)
+}
+
+func (r *limitedRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*Task, error) {
+	if err := r.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer r.release()
+	return r.next.FindByIDs(ctx, ids)
+}
+
+func (r *limitedRepository) FindAll(ctx context.Context, query TaskQuery) ([]*Task, error) {
+	if err := r.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer r.release()
+	return r.next.FindAll(ctx, query)
+}
+
+// Stream holds its slot until the cursor is drained.
+func (r *limitedRepository) Stream(ctx context.Context, query TaskQuery, fn func(*Task) error) error {
+	if err := r.acquire(ctx); err != nil {
+		return err
+	}
+	defer r.release()
+	return r.next.Stream(ctx, query, fn)
+}
+
+func (r *limitedRepository) FindOneAndUpdate(ctx context.Context, id uuid.UUID, update TaskUpdate) (*Task, error) {
+	if err := r.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer r.release()
+	return r.next.FindOneAndUpdate(ctx, id, update)
+}
+
+func (r *limitedRepository) UpdateMany(ctx context.Context, updates map[uuid.UUID]TaskUpdate) ([]uuid.UUID, error) {
+	if err := r.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer r.release()
+	return r.next.UpdateMany(ctx, updates)
+}
+
+func (r *limitedRepository) UpsertMany(ctx context.Context, tasks []*Task) (*UpsertResult, error) {
+	if err := r.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer r.release()
+	return r.next.UpsertMany(ctx, tasks)
+}
+
+func (r *limitedRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.acquire(ctx); err != nil {
+		return err
+	}
+	defer r.release()
+	return r.next.Delete(ctx, id)
+}
+
+func (r *limitedRepository) DeleteMany(ctx context.Context, query TaskQuery) (int64, error) {
+	if err := r.acquire(ctx); err != nil {
+		return 0, err
+	}
+	defer r.release()
+	return r.next.DeleteMany(ctx, query)
+}
+
+func (r *limitedRepository) CountBy(ctx context.Context, field string, query TaskQuery) (map[string]int64, error) {
+	if err := r.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer r.release()
+	return r.next.CountBy(ctx, field, query)
+}
+
+func (r *limitedRepository) CountCompletedByDay(ctx context.Context, from, to int64, loc *time.Location) (map[string]int64, error) {
+	if err := r.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer r.release()
+	return r.next.CountCompletedByDay(ctx, from, to, loc)
+}
+
+func (r *limitedRepository) HealthCheck(ctx context.Context) error {
+	if err := r.acquire(ctx); err != nil {
+		return err
+	}
+	defer r.release()
+	return r.next.HealthCheck(ctx)
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencyRepository implements TaskRepository; FindAll records how many
+// calls overlap
+type concurrencyRepository struct {
+	TaskRepository
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (r *concurrencyRepository) FindAll(ctx context.Context, query TaskQuery) ([]*Task, error) {
+	n := r.running.Add(1)
+	defer r.running.Add(-1)
+	for {
+		peak := r.peak.Load()
+		if n <= peak || r.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	return nil, nil
+}
+
+// TestLimitedRepositorySerializes tests that a limit of one runs operations one at a time
+func TestLimitedRepositorySerializes(t *testing.T) {
+	inner := &concurrencyRepository{}
+	repo := NewLimitedRepository(inner, 1)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.FindAll(context.Background(), TaskQuery{}); err != nil {
+				t.Errorf("FindAll() returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak := inner.peak.Load(); peak != 1 {
+		t.Errorf("expected at most 1 concurrent operation, saw %d", peak)
+	}
+}
+
+// TestLimitedRepositoryUnavailable tests that an operation which cannot get a
+// slot before its deadline fails with ErrUnavailable
+func TestLimitedRepositoryUnavailable(t *testing.T) {
+	inner := &blockingRepository{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(inner.release)
+	repo := NewLimitedRepository(inner, 1)
+
+	go repo.FindAll(context.Background(), TaskQuery{})
+	<-inner.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := repo.FindAll(ctx, TaskQuery{})
+	if !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the error to wrap context.DeadlineExceeded, got %v", err)
+	}
+}
+
+// TestLimitedRepositoryUnavailableWithoutDeadline tests that an operation
+// without a deadline stops waiting for a slot too
+func TestLimitedRepositoryUnavailableWithoutDeadline(t *testing.T) {
+	inner := &blockingRepository{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(inner.release)
+	repo := NewLimitedRepository(inner, 1).(*limitedRepository)
+	repo.wait = 20 * time.Millisecond
+
+	go repo.FindAll(context.Background(), TaskQuery{})
+	<-inner.started
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := repo.FindAll(context.Background(), TaskQuery{})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrUnavailable) {
+			t.Errorf("expected ErrUnavailable, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the operation to give up waiting for a slot")
+	}
+}
+
+// TestLimitedRepositoryDisabled tests that a limit of zero leaves the repository unwrapped
+func TestLimitedRepositoryDisabled(t *testing.T) {
+	inner := &concurrencyRepository{}
+	if repo := NewLimitedRepository(inner, 0); repo != TaskRepository(inner) {
+		t.Errorf("expected the repository unchanged, got %T", repo)
+	}
+}
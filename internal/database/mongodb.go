@@ -2,57 +2,162 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
+type MongoConfig struct {
+	URI        string
+	Database   string
+	Collection string
+	// SlowQueryThreshold logs repository operations slower than this; zero disables it
+	SlowQueryThreshold time.Duration
+	// WriteConcern is "majority" or a number of nodes; empty keeps the URI/driver default
+	WriteConcern string
+	// Journal makes writes wait for the on-disk journal before being acknowledged
+	Journal bool
+	// ReadPreference is a mode such as "primary" or "secondaryPreferred";
+	// empty keeps the URI/driver default
+	ReadPreference string
+	// CacheSize is how many tasks FindByID keeps in memory; zero disables the cache
+	CacheSize int
+	CacheTTL  time.Duration
+	// MaxConcurrentOps caps repository operations running at once; zero
+	// leaves them unlimited
+	MaxConcurrentOps int
+	// RedactUsername hides the username as well as the password when the URI
+	// appears in errors
+	RedactUsername bool
+}
+
+// clientOptions builds the driver options for cfg. Settings given here win
+// over the same settings in the URI.
+func (cfg MongoConfig) clientOptions() (*options.ClientOptions, error) {
+	clientOptions := options.Client().ApplyURI(cfg.URI)
+
+	if cfg.WriteConcern != "" || cfg.Journal {
+		wc := &writeconcern.WriteConcern{}
+		switch cfg.WriteConcern {
+		case "":
+		case "majority":
+			wc.W = "majority"
+		default:
+			w, err := strconv.Atoi(cfg.WriteConcern)
+			if err != nil || w < 0 {
+				return nil, fmt.Errorf("invalid write concern %q: must be majority or a number of nodes", cfg.WriteConcern)
+			}
+			wc.W = w
+		}
+		if cfg.Journal {
+			wc.Journal = &cfg.Journal
+		}
+		clientOptions.SetWriteConcern(wc)
+	}
+
+	if cfg.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(cfg.ReadPreference)
+		if err != nil {
+			return nil, fmt.Errorf("invalid read preference: %w", err)
+		}
+		rp, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid read preference: %w", err)
+		}
+		clientOptions.SetReadPreference(rp)
+	}
+
+	return clientOptions, nil
+}
+
 type MongoDatabase struct {
 	client   *mongo.Client
 	database *mongo.Database
-	taskRepo *MongoTaskRepository
+	taskRepo TaskRepository
+	inFlight *inFlightRepository
+	cache    *CachingRepository
 	logger   *slog.Logger
 }
 
-func NewMongoDatabase(ctx context.Context, uri, dbName string) (*MongoDatabase, error) {
+func NewMongoDatabase(ctx context.Context, cfg MongoConfig) (*MongoDatabase, error) {
 	logger := slog.Default()
 
-	clientOptions := options.Client().ApplyURI(uri)
+	clientOptions, err := cfg.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("MongoDB client settings",
+		"write_concern", settingOrDefault(cfg.WriteConcern),
+		"journal", cfg.Journal,
+		"read_preference", settingOrDefault(cfg.ReadPreference),
+	)
+
+	uri := RedactURI(cfg.URI, cfg.RedactUsername)
 
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
+		return nil, fmt.Errorf("failed to connect to MongoDB at %s: %w", uri, err)
 	}
 
 	if err := client.Ping(ctx, nil); err != nil {
-		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
+		// Release the client's monitoring goroutines; callers may retry
+		client.Disconnect(context.Background())
+		return nil, fmt.Errorf("failed to ping MongoDB at %s: %w", uri, err)
 	}
 
-	database := client.Database(dbName)
+	database := client.Database(cfg.Database)
 
-	taskRepo := &MongoTaskRepository{
-		collection: database.Collection("tasks"),
-		logger:     logger,
-	}
+	taskRepo := NewMongoTaskRepository(database.Collection(cfg.Collection), logger)
+	taskRepo.ensureExpiryIndex(ctx)
+	inFlight := newInFlightRepository(taskRepo)
 
-	return &MongoDatabase{
+	// Queueing for a slot happens outside the slow query timing
+	m := &MongoDatabase{
 		client:   client,
 		database: database,
-		taskRepo: taskRepo,
+		taskRepo: NewLimitedRepository(NewSlowQueryRepository(inFlight, cfg.SlowQueryThreshold, logger), cfg.MaxConcurrentOps),
+		inFlight: inFlight,
 		logger:   logger,
-	}, nil
+	}
+
+	// The cache sits outermost so hits skip slow-query accounting
+	if cfg.CacheSize > 0 {
+		m.cache = NewCachingRepository(m.taskRepo, cfg.CacheSize, cfg.CacheTTL)
+		m.taskRepo = m.cache
+		logger.Info("Task cache enabled", "size", cfg.CacheSize, "ttl", cfg.CacheTTL)
+	}
+
+	return m, nil
+}
+
+func settingOrDefault(value string) string {
+	if value == "" {
+		return "default"
+	}
+	return value
 }
 
 func (m *MongoDatabase) Ping(ctx context.Context) error {
 	return m.client.Ping(ctx, nil)
 }
 
+// Disconnect stops new repository operations, waits for running ones until
+// ctx ends, then closes the client. Operations still running at that point
+// fail as their connections close.
 func (m *MongoDatabase) Disconnect(ctx context.Context) error {
+	if err := m.inFlight.drain(ctx); err != nil {
+		m.logger.Warn("Disconnecting with repository operations still running", "error", err)
+	}
 	return m.client.Disconnect(ctx)
 }
 
@@ -60,11 +165,43 @@ func (m *MongoDatabase) GetTaskRepository() TaskRepository {
 	return m.taskRepo
 }
 
+// CacheStats returns the task cache counters; ok is false when caching is disabled.
+func (m *MongoDatabase) CacheStats() (stats CacheStats, ok bool) {
+	if m.cache == nil {
+		return CacheStats{}, false
+	}
+	return m.cache.Stats(), true
+}
+
 type MongoTaskRepository struct {
 	collection *mongo.Collection
 	logger     *slog.Logger
 }
 
+// NewMongoTaskRepository stores tasks in collection. It is the bare
+// repository, without the limiting, slow query logging and caching
+// NewMongoDatabase wraps it in, and it does not create the expiry index.
+func NewMongoTaskRepository(collection *mongo.Collection, logger *slog.Logger) *MongoTaskRepository {
+	return &MongoTaskRepository{
+		collection: collection,
+		logger:     logger,
+	}
+}
+
+// ensureExpiryIndex creates the TTL index that deletes tasks once their
+// expiresAt has passed. Creating an existing index is a no-op. Without the
+// index tasks never expire, which is not worth refusing to start over, so a
+// failure is only logged.
+func (r *MongoTaskRepository) ensureExpiryIndex(ctx context.Context) {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetName("expiresAt_ttl").SetExpireAfterSeconds(0),
+	}
+	if _, err := r.collection.Indexes().CreateOne(ctx, index); err != nil {
+		r.logger.Warn("Failed to create task expiry index; tasks will not expire", "error", err)
+	}
+}
+
 func (r *MongoTaskRepository) Create(ctx context.Context, task *Task) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -81,6 +218,42 @@ func (r *MongoTaskRepository) Create(ctx context.Context, task *Task) error {
 	return nil
 }
 
+// CreateMany inserts the tasks in a transaction, which needs a replica set or
+// sharded cluster.
+func (r *MongoTaskRepository) CreateMany(ctx context.Context, tasks []*Task) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	r.logger.Debug("Creating tasks in MongoDB transaction", "count", len(tasks))
+
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	documents := make([]any, len(tasks))
+	for i, task := range tasks {
+		documents[i] = task
+	}
+
+	session, err := r.collection.Database().Client().StartSession()
+	if err != nil {
+		r.logger.Error("MongoDB session start failed", "error", err)
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return r.collection.InsertMany(sc, documents)
+	})
+	if err != nil {
+		r.logger.Error("MongoDB batch insert failed", "error", err, "count", len(tasks))
+		return fmt.Errorf("failed to create tasks: %w", err)
+	}
+
+	r.logger.Debug("Tasks created in MongoDB", "count", len(tasks))
+	return nil
+}
+
 func (r *MongoTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*Task, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -104,53 +277,480 @@ func (r *MongoTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*Task
 	return &task, nil
 }
 
-func (r *MongoTaskRepository) FindAll(ctx context.Context) ([]*Task, error) {
+func (r *MongoTaskRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	r.logger.Debug("Finding all tasks in MongoDB")
+	r.logger.Debug("Checking task exists in MongoDB", "task_id", id)
 
-	cursor, err := r.collection.Find(ctx, bson.M{})
+	// Counting with a limit stops at the first match and never decodes the document
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id}, options.Count().SetLimit(1))
+	if err != nil {
+		r.logger.Error("MongoDB exists check failed", "error", err, "task_id", id)
+		return false, fmt.Errorf("failed to check task: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *MongoTaskRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	r.logger.Debug("Finding tasks by IDs in MongoDB", "count", len(ids))
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		r.logger.Error("MongoDB find by IDs failed", "error", err)
+		return nil, fmt.Errorf("failed to find tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	tasks, err := r.decodeTasks(ctx, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.Debug("Tasks by IDs retrieved from MongoDB", "requested", len(ids), "found", len(tasks))
+	return tasks, nil
+}
+
+func (r *MongoTaskRepository) FindAll(ctx context.Context, query TaskQuery) ([]*Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := queryFilter(query)
+
+	r.logger.Debug("Finding all tasks in MongoDB", "filter", filter, "limit", query.Limit)
+
+	cursor, err := r.collection.Find(ctx, filter, queryOptions(query))
 	if err != nil {
 		r.logger.Error("MongoDB find all failed", "error", err)
 		return nil, fmt.Errorf("failed to find tasks: %w", err)
 	}
 	defer cursor.Close(ctx)
 
-	var tasks []*Task
-	if err := cursor.All(ctx, &tasks); err != nil {
-		r.logger.Error("MongoDB decode failed", "error", err)
-		return nil, fmt.Errorf("failed to decode tasks: %w", err)
+	tasks, err := r.decodeTasks(ctx, cursor)
+	if err != nil {
+		return nil, err
 	}
 
 	r.logger.Debug("All tasks retrieved from MongoDB", "count", len(tasks))
 	return tasks, nil
 }
 
-func (r *MongoTaskRepository) Update(ctx context.Context, id uuid.UUID, task *Task) error {
+// decodeTasks reads every task left in cursor. Documents that do not decode
+// as a Task, such as ones another tool wrote with a non-UUID _id, are logged
+// and skipped instead of failing the whole query.
+func (r *MongoTaskRepository) decodeTasks(ctx context.Context, cursor *mongo.Cursor) ([]*Task, error) {
+	tasks := []*Task{}
+	for cursor.Next(ctx) {
+		var task Task
+		if err := cursor.Decode(&task); err != nil {
+			r.skipUndecodable(cursor, err)
+			continue
+		}
+		tasks = append(tasks, &task)
+	}
+
+	if err := cursor.Err(); err != nil {
+		r.logger.Error("MongoDB cursor failed", "error", err)
+		return nil, fmt.Errorf("failed to decode tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *MongoTaskRepository) skipUndecodable(cursor *mongo.Cursor, err error) {
+	r.logger.Warn("Skipping undecodable task document", "error", err, "_id", cursor.Current.Lookup("_id").String())
+}
+
+func (r *MongoTaskRepository) Stream(ctx context.Context, query TaskQuery, fn func(*Task) error) error {
+	filter := queryFilter(query)
+
+	r.logger.Debug("Streaming tasks from MongoDB", "filter", filter, "limit", query.Limit)
+
+	cursor, err := r.collection.Find(ctx, filter, queryOptions(query))
+	if err != nil {
+		r.logger.Error("MongoDB stream find failed", "error", err)
+		return fmt.Errorf("failed to find tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		var task Task
+		if err := cursor.Decode(&task); err != nil {
+			r.skipUndecodable(cursor, err)
+			continue
+		}
+		if err := fn(&task); err != nil {
+			return err
+		}
+		count++
+	}
+
+	if err := cursor.Err(); err != nil {
+		r.logger.Error("MongoDB cursor failed", "error", err)
+		return fmt.Errorf("failed to stream tasks: %w", err)
+	}
+
+	r.logger.Debug("Tasks streamed from MongoDB", "count", count)
+	return nil
+}
+
+func (r *MongoTaskRepository) CountBy(ctx context.Context, field string, query TaskQuery) (map[string]int64, error) {
+	missing, ok := GroupableFields[field]
+	if !ok {
+		return nil, fmt.Errorf("cannot group tasks by %q", field)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	r.logger.Debug("Updating task in MongoDB", "task_id", id)
+	filter := queryFilter(query)
 
-	filter := bson.M{"_id": id}
-	update := bson.M{
-		"$set": bson.M{
-			"title":       task.Title,
-			"description": task.Description,
-			"completed":   task.Completed,
-			"updatedAt":   task.UpdatedAt,
-		},
+	r.logger.Debug("Counting tasks by field in MongoDB", "field", field, "filter", filter)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"$ifNull": bson.A{"$" + field, missing}},
+			"count": bson.M{"$sum": 1},
+		}}},
 	}
 
-	_, err := r.collection.UpdateOne(ctx, filter, update)
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		r.logger.Error("MongoDB update failed", "error", err, "task_id", id)
-		return fmt.Errorf("failed to update task: %w", err)
+		r.logger.Error("MongoDB count by field failed", "error", err, "field", field)
+		return nil, fmt.Errorf("failed to count tasks: %w", err)
 	}
+	defer cursor.Close(ctx)
 
-	r.logger.Debug("Task updated in MongoDB", "task_id", id)
-	return nil
+	var groups []struct {
+		Value any   `bson:"_id"`
+		Count int64 `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		r.logger.Error("MongoDB decode failed", "error", err)
+		return nil, fmt.Errorf("failed to decode task counts: %w", err)
+	}
+
+	counts := make(map[string]int64, len(groups))
+	for _, group := range groups {
+		counts[fmt.Sprint(group.Value)] += group.Count
+	}
+
+	r.logger.Debug("Task counts retrieved from MongoDB", "field", field, "groups", len(counts))
+	return counts, nil
+}
+
+func (r *MongoTaskRepository) CountCompletedByDay(ctx context.Context, from, to int64, loc *time.Location) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	r.logger.Debug("Counting completed tasks by day in MongoDB", "from", from, "to", to, "timezone", loc.String())
+
+	cursor, err := r.collection.Aggregate(ctx, completionTrendPipeline(from, to, loc))
+	if err != nil {
+		r.logger.Error("MongoDB completion trend failed", "error", err)
+		return nil, fmt.Errorf("failed to count completed tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var days []struct {
+		Date  string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &days); err != nil {
+		r.logger.Error("MongoDB decode failed", "error", err)
+		return nil, fmt.Errorf("failed to decode completion counts: %w", err)
+	}
+
+	counts := make(map[string]int64, len(days))
+	for _, day := range days {
+		counts[day.Date] = day.Count
+	}
+
+	r.logger.Debug("Completion counts retrieved from MongoDB", "days", len(counts))
+	return counts, nil
+}
+
+// completionTrendPipeline groups completions in [from, to) by their date in
+// loc. completedAt is in seconds, so it is scaled to a BSON date first.
+func completionTrendPipeline(from, to int64, loc *time.Location) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"completed":   true,
+			"completedAt": bson.M{"$gte": from, "$lt": to},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$dateToString": bson.M{
+				"format":   "%Y-%m-%d",
+				"date":     bson.M{"$toDate": bson.M{"$multiply": bson.A{"$completedAt", 1000}}},
+				"timezone": loc.String(),
+			}},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+}
+
+func (r *MongoTaskRepository) FindOneAndUpdate(ctx context.Context, id uuid.UUID, update TaskUpdate) (*Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	r.logger.Debug("Finding and updating task in MongoDB", "task_id", id)
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var task Task
+	err := r.collection.FindOneAndUpdate(ctx, updateFilter(id, update), updatePipeline(update), opts).Decode(&task)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			r.logger.Debug("Task not found in MongoDB", "task_id", id)
+			return nil, nil
+		}
+		r.logger.Error("MongoDB find and update failed", "error", err, "task_id", id)
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	r.logger.Debug("Task found and updated in MongoDB", "task_id", id)
+	return &task, nil
+}
+
+// errUpdateMissed aborts the UpdateMany transaction when an update misses.
+var errUpdateMissed = errors.New("update missed")
+
+// UpdateMany runs the updates in a transaction, which needs a replica set or
+// sharded cluster.
+func (r *MongoTaskRepository) UpdateMany(ctx context.Context, updates map[uuid.UUID]TaskUpdate) ([]uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	r.logger.Debug("Updating tasks in MongoDB transaction", "count", len(updates))
+
+	session, err := r.collection.Database().Client().StartSession()
+	if err != nil {
+		r.logger.Error("MongoDB session start failed", "error", err)
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	var missed []uuid.UUID
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		// The transaction may be retried, so start over each time
+		missed = nil
+		for id, update := range updates {
+			result, err := r.collection.UpdateOne(sc, updateFilter(id, update), updatePipeline(update))
+			if err != nil {
+				return nil, err
+			}
+			if result.MatchedCount == 0 {
+				missed = append(missed, id)
+			}
+		}
+		if len(missed) > 0 {
+			return nil, errUpdateMissed
+		}
+		return nil, nil
+	})
+	if errors.Is(err, errUpdateMissed) {
+		r.logger.Debug("MongoDB batch update missed tasks", "count", len(updates), "missed", len(missed))
+		return missed, nil
+	}
+	if err != nil {
+		r.logger.Error("MongoDB batch update failed", "error", err, "count", len(updates))
+		return nil, fmt.Errorf("failed to update tasks: %w", err)
+	}
+
+	r.logger.Debug("Tasks updated in MongoDB", "count", len(updates))
+	return nil, nil
+}
+
+// UpsertMany sends every upsert in one unordered bulk write. The filter only
+// matches a stored copy that is not newer than the task, so a newer one makes
+// the upsert try to insert a second task with the same _id, and the duplicate
+// key error marks the conflict.
+func (r *MongoTaskRepository) UpsertMany(ctx context.Context, tasks []*Task) (*UpsertResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	r.logger.Debug("Upserting tasks in MongoDB", "count", len(tasks))
+
+	if len(tasks) == 0 {
+		return &UpsertResult{}, nil
+	}
+
+	opts := options.BulkWrite().SetOrdered(false)
+	res, err := r.collection.BulkWrite(ctx, upsertModels(tasks), opts)
+
+	result, err := upsertOutcome(tasks, res, err)
+	if err != nil {
+		r.logger.Error("MongoDB bulk upsert failed", "error", err, "count", len(tasks))
+		return nil, fmt.Errorf("failed to upsert tasks: %w", err)
+	}
+
+	r.logger.Debug("Tasks upserted in MongoDB",
+		"inserted", len(result.Inserted),
+		"updated", len(result.Updated),
+		"conflicts", len(result.Conflicts),
+	)
+	return result, nil
+}
+
+// upsertModels builds one upsert per task, in order. createdAt and archived
+// are only written when the task is new.
+func upsertModels(tasks []*Task) []mongo.WriteModel {
+	models := make([]mongo.WriteModel, len(tasks))
+	for i, task := range tasks {
+		update := updateDocument(task)
+		update["$setOnInsert"] = bson.M{
+			"createdAt": task.CreatedAt,
+			"archived":  task.Archived,
+		}
+
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": task.ID, "updatedAt": bson.M{"$lte": task.UpdatedAt}}).
+			SetUpdate(update).
+			SetUpsert(true)
+	}
+	return models
+}
+
+// upsertOutcome sorts the tasks of a bulk upsert by what happened to them.
+// Duplicate key errors are conflicts; any other error fails the whole call.
+func upsertOutcome(tasks []*Task, res *mongo.BulkWriteResult, err error) (*UpsertResult, error) {
+	conflicted := make(map[int]bool)
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+			return nil, err
+		}
+		for _, writeErr := range bulkErr.WriteErrors {
+			if !mongo.IsDuplicateKeyError(writeErr) {
+				return nil, err
+			}
+			conflicted[writeErr.Index] = true
+		}
+	}
+
+	result := &UpsertResult{}
+	for i, task := range tasks {
+		_, inserted := res.UpsertedIDs[int64(i)]
+		switch {
+		case conflicted[i]:
+			result.Conflicts = append(result.Conflicts, task.ID)
+		case inserted:
+			result.Inserted = append(result.Inserted, task.ID)
+		default:
+			result.Updated = append(result.Updated, task.ID)
+		}
+	}
+	return result, nil
+}
+
+// updateDocument sets the fields an update may change. Unset optional fields
+// are removed rather than stored as null.
+func updateDocument(task *Task) bson.M {
+	set := bson.M{
+		"title":       task.Title,
+		"description": task.Description,
+		"completed":   task.Completed,
+		"updatedAt":   task.UpdatedAt,
+	}
+	unset := bson.M{}
+
+	if task.AssigneeID != nil {
+		set["assigneeId"] = *task.AssigneeID
+	} else {
+		unset["assigneeId"] = ""
+	}
+
+	if task.CompletedAt != nil {
+		set["completedAt"] = *task.CompletedAt
+	} else {
+		unset["completedAt"] = ""
+	}
+
+	if task.ExpiresAt != nil {
+		set["expiresAt"] = *task.ExpiresAt
+	} else {
+		unset["expiresAt"] = ""
+	}
+
+	if len(task.BlockedBy) > 0 {
+		set["blockedBy"] = task.BlockedBy
+	} else {
+		unset["blockedBy"] = ""
+	}
+
+	update := bson.M{"$set": set}
+	// MongoDB before 5.0 rejects an empty $unset
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update
+}
+
+// updateFilter matches the task update applies to, as long as it was not
+// updated after update.UnmodifiedSince.
+func updateFilter(id uuid.UUID, update TaskUpdate) bson.M {
+	filter := bson.M{"_id": id}
+	if update.UnmodifiedSince != nil {
+		filter["updatedAt"] = bson.M{"$lte": *update.UnmodifiedSince}
+	}
+	return filter
+}
+
+// updatePipeline expresses update as an aggregation pipeline, so whether
+// completedAt is stamped can depend on the stored completed flag. Strings are
+// wrapped in $literal because the pipeline would read "$..." as a field path.
+func updatePipeline(update TaskUpdate) mongo.Pipeline {
+	set := bson.D{}
+	if update.Title != nil {
+		set = append(set, bson.E{Key: "title", Value: bson.M{"$literal": *update.Title}})
+	}
+	if update.Description != nil {
+		set = append(set, bson.E{Key: "description", Value: bson.M{"$literal": *update.Description}})
+	}
+
+	pipeline := mongo.Pipeline{}
+	if update.Completed != nil {
+		set = append(set, bson.E{Key: "completed", Value: *update.Completed})
+		if *update.Completed {
+			// Every reference in a stage sees the document as it was before it
+			set = append(set, bson.E{Key: "completedAt", Value: bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$completed", true}}, "$completedAt", update.UpdatedAt,
+			}}})
+		} else {
+			pipeline = append(pipeline, bson.D{{Key: "$unset", Value: "completedAt"}})
+		}
+	}
+	if update.ExpiresAt != nil {
+		if update.ExpiresAt.IsZero() {
+			pipeline = append(pipeline, bson.D{{Key: "$unset", Value: "expiresAt"}})
+		} else {
+			set = append(set, bson.E{Key: "expiresAt", Value: *update.ExpiresAt})
+		}
+	}
+	if update.BlockedBy != nil {
+		if len(*update.BlockedBy) == 0 {
+			pipeline = append(pipeline, bson.D{{Key: "$unset", Value: "blockedBy"}})
+		} else {
+			set = append(set, bson.E{Key: "blockedBy", Value: bson.M{"$literal": *update.BlockedBy}})
+		}
+	}
+	if update.Archived != nil {
+		set = append(set, bson.E{Key: "archived", Value: *update.Archived})
+	}
+	if update.AssigneeID != nil {
+		if *update.AssigneeID == "" {
+			pipeline = append(pipeline, bson.D{{Key: "$unset", Value: "assigneeId"}})
+		} else {
+			set = append(set, bson.E{Key: "assigneeId", Value: bson.M{"$literal": *update.AssigneeID}})
+		}
+	}
+	set = append(set, bson.E{Key: "updatedAt", Value: update.UpdatedAt})
+
+	return append(mongo.Pipeline{{{Key: "$set", Value: set}}}, pipeline...)
 }
 
 func (r *MongoTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
@@ -169,3 +769,137 @@ func (r *MongoTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	r.logger.Debug("Task deleted from MongoDB", "task_id", id)
 	return nil
 }
+
+func (r *MongoTaskRepository) DeleteMany(ctx context.Context, query TaskQuery) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	r.logger.Debug("Deleting tasks from MongoDB", "query", query)
+
+	result, err := r.collection.DeleteMany(ctx, queryFilter(query))
+	if err != nil {
+		r.logger.Error("MongoDB batch delete failed", "error", err)
+		return 0, fmt.Errorf("failed to delete tasks: %w", err)
+	}
+
+	r.logger.Debug("Tasks deleted from MongoDB", "count", result.DeletedCount)
+	return result.DeletedCount, nil
+}
+
+func (r *MongoTaskRepository) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := r.collection.EstimatedDocumentCount(ctx); err != nil {
+		r.logger.Error("MongoDB health check failed", "error", err)
+		return fmt.Errorf("tasks collection is not queryable: %w", err)
+	}
+
+	return nil
+}
+
+func queryFilter(query TaskQuery) bson.M {
+	filter := bson.M{}
+
+	if query.AssigneeID != nil {
+		filter["assigneeId"] = *query.AssigneeID
+	}
+
+	if query.Completed != nil {
+		filter["completed"] = *query.Completed
+	}
+
+	if query.CreatedFrom != nil || query.CreatedTo != nil {
+		createdAt := bson.M{}
+		if query.CreatedFrom != nil {
+			createdAt["$gte"] = *query.CreatedFrom
+		}
+		if query.CreatedTo != nil {
+			createdAt["$lte"] = *query.CreatedTo
+		}
+		filter["createdAt"] = createdAt
+	}
+
+	if query.UpdatedSince != nil {
+		filter["updatedAt"] = bson.M{"$gt": *query.UpdatedSince}
+	}
+
+	var bounds bson.A
+	if query.After != nil {
+		bounds = append(bounds, positionFilter(*query.After, "$gt"))
+	}
+	if query.Before != nil {
+		bounds = append(bounds, positionFilter(*query.Before, "$lt"))
+	}
+	for _, condition := range query.Conditions {
+		bounds = append(bounds, conditionFilter(condition))
+	}
+	if len(bounds) > 0 {
+		filter["$and"] = bounds
+	}
+
+	if query.Archived != nil {
+		if *query.Archived {
+			filter["archived"] = true
+		} else {
+			// Tasks stored before archiving existed have no archived field
+			filter["archived"] = bson.M{"$ne": true}
+		}
+	}
+
+	if query.HasDescription != nil {
+		// A missing description counts as none, like an empty one
+		if *query.HasDescription {
+			filter["description"] = bson.M{"$nin": bson.A{"", nil}}
+		} else {
+			filter["description"] = bson.M{"$in": bson.A{"", nil}}
+		}
+	}
+
+	return filter
+}
+
+// positionFilter matches tasks on the op side of pos in (createdAt, _id) order.
+func positionFilter(pos Position, op string) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"createdAt": bson.M{op: pos.CreatedAt}},
+		bson.M{"createdAt": pos.CreatedAt, "_id": bson.M{op: pos.ID}},
+	}}
+}
+
+// queryOptions translates the non-filter parts of a TaskQuery into find options.
+func queryOptions(query TaskQuery) *options.FindOptions {
+	opts := options.Find()
+
+	keys := query.Sort
+	if len(keys) == 0 {
+		keys = DefaultSort
+	}
+
+	sort := bson.D{}
+	direction := 1
+	for _, key := range keys {
+		direction = 1
+		if key.Descending {
+			direction = -1
+		}
+		sort = append(sort, bson.E{Key: key.Field, Value: direction})
+	}
+	opts.SetSort(append(sort, bson.E{Key: "_id", Value: direction}))
+
+	if query.Offset > 0 {
+		opts.SetSkip(int64(query.Offset))
+	}
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+	if len(query.Fields) > 0 {
+		// _id is projected unless excluded, and Task cannot decode without it
+		projection := bson.D{}
+		for _, field := range query.Fields {
+			projection = append(projection, bson.E{Key: field, Value: 1})
+		}
+		opts.SetProjection(projection)
+	}
+	return opts
+}
@@ -0,0 +1,233 @@
+package database
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newIntegrationRepository returns a repository on a fresh collection of the
+// MongoDB at MONGO_TEST_URI, skipping the test when it is not set. The
+// database is dropped when the test ends.
+func newIntegrationRepository(t *testing.T) *MongoTaskRepository {
+	t.Helper()
+
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI not set; skipping MongoDB integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", RedactURI(uri, true), err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
+		t.Fatalf("failed to ping %s: %v", RedactURI(uri, true), err)
+	}
+
+	database := client.Database("restgo_test_" + uuid.NewString()[:8])
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		database.Drop(ctx)
+		client.Disconnect(ctx)
+	})
+
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	return NewMongoTaskRepository(database.Collection("tasks"), logger)
+}
+
+// TestIntegrationMongoCreateFind tests that a created task reads back unchanged
+func TestIntegrationMongoCreateFind(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+
+	assignee := "alice"
+	completedAt := int64(1700000100)
+	task := &Task{
+		ID:          uuid.New(),
+		Title:       "Write the integration tests",
+		Description: "Against a real MongoDB",
+		Completed:   true,
+		AssigneeID:  &assignee,
+		CompletedAt: &completedAt,
+		CreatedAt:   1700000000,
+		UpdatedAt:   1700000100,
+	}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() returned error: %v", err)
+	}
+
+	got, err := repo.FindByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("FindByID() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, task) {
+		t.Errorf("expected %+v, got %+v", task, got)
+	}
+
+	if err := repo.Create(ctx, task); !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("expected a duplicate key error creating the task twice, got %v", err)
+	}
+}
+
+// TestIntegrationMongoNotFound tests that missing tasks are (nil, nil) or a no-op, never an error
+func TestIntegrationMongoNotFound(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+	id := uuid.New()
+
+	task, err := repo.FindByID(ctx, id)
+	if err != nil || task != nil {
+		t.Errorf("FindByID() = %v, %v; expected nil, nil", task, err)
+	}
+
+	exists, err := repo.Exists(ctx, id)
+	if err != nil || exists {
+		t.Errorf("Exists() = %v, %v; expected false, nil", exists, err)
+	}
+
+	title := "Renamed"
+	updated, err := repo.FindOneAndUpdate(ctx, id, TaskUpdate{Title: &title, UpdatedAt: 1700000000})
+	if err != nil || updated != nil {
+		t.Errorf("FindOneAndUpdate() = %v, %v; expected nil, nil", updated, err)
+	}
+
+	if err := repo.Delete(ctx, id); err != nil {
+		t.Errorf("Delete() returned error: %v", err)
+	}
+
+	// FindOneAndUpdate must not have upserted the missing task
+	if task, _ := repo.FindByID(ctx, id); task != nil {
+		t.Errorf("expected no task after updating a missing one, got %+v", task)
+	}
+}
+
+// TestIntegrationMongoFindAll tests filtering, ordering and paging
+func TestIntegrationMongoFindAll(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+
+	ids := make([]uuid.UUID, 4)
+	for i := range ids {
+		ids[i] = uuid.New()
+		err := repo.Create(ctx, &Task{
+			ID:        ids[i],
+			Title:     "Task",
+			Completed: i%2 == 1,
+			CreatedAt: 1700000000 + int64(i),
+			UpdatedAt: 1700000000 + int64(i),
+		})
+		if err != nil {
+			t.Fatalf("Create() returned error: %v", err)
+		}
+	}
+
+	completed := false
+	tests := []struct {
+		name  string
+		query TaskQuery
+		want  []uuid.UUID
+	}{
+		{"everything oldest first", TaskQuery{}, ids},
+		{"newest first", TaskQuery{Sort: []SortKey{{Field: "createdAt", Descending: true}}}, []uuid.UUID{ids[3], ids[2], ids[1], ids[0]}},
+		{"filtered", TaskQuery{Completed: &completed}, []uuid.UUID{ids[0], ids[2]}},
+		{"paged", TaskQuery{Offset: 1, Limit: 2}, []uuid.UUID{ids[1], ids[2]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := repo.FindAll(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("FindAll() returned error: %v", err)
+			}
+
+			got := make([]uuid.UUID, len(found))
+			for i, task := range found {
+				got[i] = task.ID
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestIntegrationMongoUpdateDelete tests that updates are stored and deletes remove the task
+func TestIntegrationMongoUpdateDelete(t *testing.T) {
+	repo := newIntegrationRepository(t)
+	ctx := context.Background()
+
+	task := &Task{ID: uuid.New(), Title: "Original", CreatedAt: 1700000000, UpdatedAt: 1700000000}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() returned error: %v", err)
+	}
+
+	title, withDescription := "Updated", "Now with a description"
+	change := TaskUpdate{Title: &title, Description: &withDescription, UpdatedAt: 1700000050}
+	if _, err := repo.FindOneAndUpdate(ctx, task.ID, change); err != nil {
+		t.Fatalf("FindOneAndUpdate() returned error: %v", err)
+	}
+	change.Apply(task)
+
+	got, err := repo.FindByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("FindByID() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, task) {
+		t.Errorf("expected %+v after FindOneAndUpdate(), got %+v", task, got)
+	}
+
+	description := ""
+	before, err := repo.FindOneAndUpdate(ctx, task.ID, TaskUpdate{Description: &description, UpdatedAt: 1700000060})
+	if err != nil {
+		t.Fatalf("FindOneAndUpdate() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(before, task) {
+		t.Errorf("expected FindOneAndUpdate() to return %+v as it was, got %+v", task, before)
+	}
+	got, err = repo.FindByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("FindByID() returned error: %v", err)
+	}
+	if got == nil || got.Description != "" || got.Title != "Updated" || got.UpdatedAt != 1700000060 {
+		t.Errorf("expected only the description and updatedAt to change, got %+v", got)
+	}
+
+	if err := repo.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("Delete() returned error: %v", err)
+	}
+	if got, _ := repo.FindByID(ctx, task.ID); got != nil {
+		t.Errorf("expected no task after Delete(), got %+v", got)
+	}
+}
+
+// TestIntegrationMongoTimeout tests that an expired context fails the operation as a timeout
+func TestIntegrationMongoTimeout(t *testing.T) {
+	repo := newIntegrationRepository(t)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	if _, err := repo.FindByID(ctx, uuid.New()); !mongo.IsTimeout(err) {
+		t.Errorf("expected FindByID() to time out, got %v", err)
+	}
+	if _, err := repo.FindAll(ctx, TaskQuery{}); !mongo.IsTimeout(err) {
+		t.Errorf("expected FindAll() to time out, got %v", err)
+	}
+	if err := repo.Create(ctx, &Task{ID: uuid.New(), Title: "Too late"}); !mongo.IsTimeout(err) {
+		t.Errorf("expected Create() to time out, got %v", err)
+	}
+}
@@ -0,0 +1,435 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// TestClientOptions tests that write concern and read preference settings reach the driver options
+func TestClientOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         MongoConfig
+		wantW       any
+		wantJournal bool
+		wantMode    readpref.Mode
+		wantErr     bool
+	}{
+		{
+			name: "defaults",
+			cfg:  MongoConfig{URI: "mongodb://localhost:27017"},
+		},
+		{
+			name:        "majority with journal",
+			cfg:         MongoConfig{URI: "mongodb://localhost:27017", WriteConcern: "majority", Journal: true},
+			wantW:       "majority",
+			wantJournal: true,
+		},
+		{
+			name:  "node count overrides the URI",
+			cfg:   MongoConfig{URI: "mongodb://localhost:27017/?w=1", WriteConcern: "2"},
+			wantW: 2,
+		},
+		{
+			name:     "secondary reads",
+			cfg:      MongoConfig{URI: "mongodb://localhost:27017", ReadPreference: "secondaryPreferred"},
+			wantMode: readpref.SecondaryPreferredMode,
+		},
+		{
+			name:    "invalid write concern",
+			cfg:     MongoConfig{URI: "mongodb://localhost:27017", WriteConcern: "all"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid read preference",
+			cfg:     MongoConfig{URI: "mongodb://localhost:27017", ReadPreference: "fastest"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := tt.cfg.clientOptions()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("clientOptions() returned error: %v", err)
+			}
+
+			if tt.wantW != nil || tt.wantJournal {
+				if opts.WriteConcern == nil {
+					t.Fatal("expected a write concern")
+				}
+				if opts.WriteConcern.W != tt.wantW {
+					t.Errorf("expected w=%v, got %v", tt.wantW, opts.WriteConcern.W)
+				}
+				if got := opts.WriteConcern.Journal != nil && *opts.WriteConcern.Journal; got != tt.wantJournal {
+					t.Errorf("expected journal=%v, got %v", tt.wantJournal, got)
+				}
+			} else if opts.WriteConcern != nil {
+				t.Errorf("expected no write concern, got %+v", opts.WriteConcern)
+			}
+
+			if tt.wantMode != 0 {
+				if opts.ReadPreference == nil || opts.ReadPreference.Mode() != tt.wantMode {
+					t.Errorf("expected read preference %s, got %v", tt.wantMode, opts.ReadPreference)
+				}
+			}
+		})
+	}
+}
+
+// TestQueryOptions tests that sort, offset and limit reach the find options
+func TestQueryOptions(t *testing.T) {
+	opts := queryOptions(TaskQuery{
+		Sort:   []SortKey{{Field: "createdAt", Descending: true}},
+		Offset: 20,
+		Limit:  10,
+	})
+
+	wantSort := bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}
+	if !reflect.DeepEqual(opts.Sort, wantSort) {
+		t.Errorf("expected sort %v, got %v", wantSort, opts.Sort)
+	}
+	if opts.Skip == nil || *opts.Skip != 20 {
+		t.Errorf("expected skip 20, got %v", opts.Skip)
+	}
+	if opts.Limit == nil || *opts.Limit != 10 {
+		t.Errorf("expected limit 10, got %v", opts.Limit)
+	}
+
+	// Without a sort the order is still fixed, so repeated calls agree
+	opts = queryOptions(TaskQuery{})
+	wantSort = bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}
+	if !reflect.DeepEqual(opts.Sort, wantSort) {
+		t.Errorf("expected default sort %v, got %v", wantSort, opts.Sort)
+	}
+	if opts.Skip != nil || opts.Limit != nil || opts.Projection != nil {
+		t.Errorf("expected no skip, limit or projection for an empty query, got %+v", opts)
+	}
+
+	opts = queryOptions(TaskQuery{Fields: []string{"title", "completed"}})
+	wantProjection := bson.D{{Key: "title", Value: 1}, {Key: "completed", Value: 1}}
+	if !reflect.DeepEqual(opts.Projection, wantProjection) {
+		t.Errorf("expected projection %v, got %v", wantProjection, opts.Projection)
+	}
+}
+
+// TestQueryFilterPosition tests that position bounds compare createdAt, then _id
+func TestQueryFilterPosition(t *testing.T) {
+	id := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	filter := queryFilter(TaskQuery{After: &Position{CreatedAt: 100, ID: id}})
+
+	want := bson.A{bson.M{"$or": bson.A{
+		bson.M{"createdAt": bson.M{"$gt": int64(100)}},
+		bson.M{"createdAt": int64(100), "_id": bson.M{"$gt": id}},
+	}}}
+	if !reflect.DeepEqual(filter["$and"], want) {
+		t.Errorf("expected %v, got %v", want, filter["$and"])
+	}
+}
+
+// TestQueryFilterDescription tests that a missing description counts as none
+func TestQueryFilterDescription(t *testing.T) {
+	for _, hasDescription := range []bool{true, false} {
+		filter := queryFilter(TaskQuery{HasDescription: &hasDescription})
+
+		want := bson.M{"$in": bson.A{"", nil}}
+		if hasDescription {
+			want = bson.M{"$nin": bson.A{"", nil}}
+		}
+		if !reflect.DeepEqual(filter["description"], want) {
+			t.Errorf("has description %v: expected %v, got %v", hasDescription, want, filter["description"])
+		}
+	}
+}
+
+// TestUpdatePipeline tests that field updates are literal and that reopening unsets completedAt
+func TestUpdatePipeline(t *testing.T) {
+	title := "$where"
+	reopen := false
+	pipeline := updatePipeline(TaskUpdate{Title: &title, Completed: &reopen, UpdatedAt: 100})
+
+	want := mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{
+			{Key: "title", Value: bson.M{"$literal": "$where"}},
+			{Key: "completed", Value: false},
+			{Key: "updatedAt", Value: int64(100)},
+		}}},
+		{{Key: "$unset", Value: "completedAt"}},
+	}
+	if !reflect.DeepEqual(pipeline, want) {
+		t.Errorf("expected %v, got %v", want, pipeline)
+	}
+}
+
+// TestUpdatePipelineExpiry tests that an expiry is set as a date and the zero
+// time removes it
+func TestUpdatePipelineExpiry(t *testing.T) {
+	expiresAt := time.Date(2025, 11, 14, 0, 0, 0, 0, time.UTC)
+	pipeline := updatePipeline(TaskUpdate{ExpiresAt: &expiresAt, UpdatedAt: 100})
+
+	want := mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{
+			{Key: "expiresAt", Value: expiresAt},
+			{Key: "updatedAt", Value: int64(100)},
+		}}},
+	}
+	if !reflect.DeepEqual(pipeline, want) {
+		t.Errorf("expected %v, got %v", want, pipeline)
+	}
+
+	pipeline = updatePipeline(TaskUpdate{ExpiresAt: &time.Time{}, UpdatedAt: 100})
+	want = mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{{Key: "updatedAt", Value: int64(100)}}}},
+		{{Key: "$unset", Value: "expiresAt"}},
+	}
+	if !reflect.DeepEqual(pipeline, want) {
+		t.Errorf("expected %v, got %v", want, pipeline)
+	}
+}
+
+// TestUpdatePipelineBlockedBy tests that blockers are set literally and an
+// empty list removes them
+func TestUpdatePipelineBlockedBy(t *testing.T) {
+	blockedBy := []uuid.UUID{uuid.New()}
+	pipeline := updatePipeline(TaskUpdate{BlockedBy: &blockedBy, UpdatedAt: 100})
+
+	want := mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{
+			{Key: "blockedBy", Value: bson.M{"$literal": blockedBy}},
+			{Key: "updatedAt", Value: int64(100)},
+		}}},
+	}
+	if !reflect.DeepEqual(pipeline, want) {
+		t.Errorf("expected %v, got %v", want, pipeline)
+	}
+
+	pipeline = updatePipeline(TaskUpdate{BlockedBy: &[]uuid.UUID{}, UpdatedAt: 100})
+	want = mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{{Key: "updatedAt", Value: int64(100)}}}},
+		{{Key: "$unset", Value: "blockedBy"}},
+	}
+	if !reflect.DeepEqual(pipeline, want) {
+		t.Errorf("expected %v, got %v", want, pipeline)
+	}
+}
+
+// TestUpdatePipelineAssignee tests that the assignee is set literally and ""
+// removes it
+func TestUpdatePipelineAssignee(t *testing.T) {
+	assigneeID := "alice"
+	pipeline := updatePipeline(TaskUpdate{AssigneeID: &assigneeID, UpdatedAt: 100})
+
+	want := mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{
+			{Key: "assigneeId", Value: bson.M{"$literal": "alice"}},
+			{Key: "updatedAt", Value: int64(100)},
+		}}},
+	}
+	if !reflect.DeepEqual(pipeline, want) {
+		t.Errorf("expected %v, got %v", want, pipeline)
+	}
+
+	unassigned := ""
+	pipeline = updatePipeline(TaskUpdate{AssigneeID: &unassigned, UpdatedAt: 100})
+	want = mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{{Key: "updatedAt", Value: int64(100)}}}},
+		{{Key: "$unset", Value: "assigneeId"}},
+	}
+	if !reflect.DeepEqual(pipeline, want) {
+		t.Errorf("expected %v, got %v", want, pipeline)
+	}
+}
+
+// TestUpdateDocument tests that only unset optional fields are removed, and
+// that no $unset is sent when there are none
+func TestUpdateDocument(t *testing.T) {
+	assigneeID := "alice"
+	completedAt := int64(100)
+	expiresAt := time.Date(2025, 11, 14, 0, 0, 0, 0, time.UTC)
+	blockedBy := []uuid.UUID{uuid.New()}
+
+	full := updateDocument(&Task{
+		Title:       "Task",
+		Completed:   true,
+		AssigneeID:  &assigneeID,
+		CompletedAt: &completedAt,
+		ExpiresAt:   &expiresAt,
+		BlockedBy:   blockedBy,
+		UpdatedAt:   100,
+	})
+	if _, ok := full["$unset"]; ok {
+		t.Errorf("expected no $unset, got %v", full["$unset"])
+	}
+
+	bare := updateDocument(&Task{Title: "Task", UpdatedAt: 100})
+	want := bson.M{"assigneeId": "", "completedAt": "", "expiresAt": "", "blockedBy": ""}
+	if !reflect.DeepEqual(bare["$unset"], want) {
+		t.Errorf("expected $unset %v, got %v", want, bare["$unset"])
+	}
+}
+
+// TestTaskUpdateApply tests that completing keeps an existing completedAt and reopening clears it
+func TestTaskUpdateApply(t *testing.T) {
+	done, open := true, false
+	completedAt := int64(50)
+
+	task := Task{Title: "Old", Completed: true, CompletedAt: &completedAt, UpdatedAt: 50}
+	TaskUpdate{Completed: &done, UpdatedAt: 100}.Apply(&task)
+	if task.CompletedAt == nil || *task.CompletedAt != 50 || task.UpdatedAt != 100 || task.Title != "Old" {
+		t.Errorf("completing a done task changed more than updatedAt: %+v", task)
+	}
+
+	TaskUpdate{Completed: &open, UpdatedAt: 200}.Apply(&task)
+	if task.Completed || task.CompletedAt != nil {
+		t.Errorf("expected reopening to clear completedAt, got %+v", task)
+	}
+
+	TaskUpdate{Completed: &done, UpdatedAt: 300}.Apply(&task)
+	if task.CompletedAt == nil || *task.CompletedAt != 300 {
+		t.Errorf("expected completedAt 300, got %v", task.CompletedAt)
+	}
+
+	TaskUpdate{Archived: &done, UpdatedAt: 350}.Apply(&task)
+	if !task.Archived || !task.Completed {
+		t.Errorf("expected archiving to only set archived, got %+v", task)
+	}
+
+	alice, nobody := "alice", ""
+	TaskUpdate{AssigneeID: &alice, UpdatedAt: 400}.Apply(&task)
+	if task.AssigneeID == nil || *task.AssigneeID != "alice" {
+		t.Errorf("expected assignee alice, got %v", task.AssigneeID)
+	}
+	TaskUpdate{AssigneeID: &nobody, UpdatedAt: 500}.Apply(&task)
+	if task.AssigneeID != nil {
+		t.Errorf("expected the assignee removed, got %v", *task.AssigneeID)
+	}
+}
+
+// TestCompletionTrendPipeline tests that days are bucketed in the requested time zone
+func TestCompletionTrendPipeline(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("LoadLocation() returned error: %v", err)
+	}
+
+	pipeline := completionTrendPipeline(100, 200, loc)
+
+	wantMatch := bson.M{"completed": true, "completedAt": bson.M{"$gte": int64(100), "$lt": int64(200)}}
+	if !reflect.DeepEqual(pipeline[0][0].Value, wantMatch) {
+		t.Errorf("expected match %v, got %v", wantMatch, pipeline[0][0].Value)
+	}
+
+	group := pipeline[1][0].Value.(bson.M)
+	dateToString := group["_id"].(bson.M)["$dateToString"].(bson.M)
+	if dateToString["timezone"] != "Europe/Berlin" || dateToString["format"] != "%Y-%m-%d" {
+		t.Errorf("expected Berlin days, got %v", dateToString)
+	}
+}
+
+// TestDecodeTasksSkipsMalformed tests that a document with a non-UUID _id is skipped, not fatal
+func TestDecodeTasksSkipsMalformed(t *testing.T) {
+	id := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	cursor, err := mongo.NewCursorFromDocuments([]any{
+		bson.M{"_id": "imported-by-hand", "title": "Malformed"},
+		Task{ID: id, Title: "Valid"},
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewCursorFromDocuments() returned error: %v", err)
+	}
+
+	var logs bytes.Buffer
+	repo := &MongoTaskRepository{logger: slog.New(slog.NewTextHandler(&logs, nil))}
+
+	tasks, err := repo.decodeTasks(context.Background(), cursor)
+	if err != nil {
+		t.Fatalf("decodeTasks() returned error: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != id {
+		t.Errorf("expected only the valid task, got %+v", tasks)
+	}
+	if !strings.Contains(logs.String(), "imported-by-hand") {
+		t.Errorf("expected the skipped _id to be logged, got %q", logs.String())
+	}
+}
+
+// BenchmarkDecodeTasks compares decoding whole tasks with decoding tasks
+// projected to their title, which is what a ?fields=title list loads
+func BenchmarkDecodeTasks(b *testing.B) {
+	description := strings.Repeat("x", 4096)
+	full := make([]any, 100)
+	projected := make([]any, 100)
+	for i := range full {
+		id := uuid.New()
+		full[i] = Task{ID: id, Title: "Benchmark", Description: description, CreatedAt: 1000, UpdatedAt: 1000}
+		projected[i] = bson.M{"_id": id, "title": "Benchmark"}
+	}
+
+	repo := &MongoTaskRepository{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	for _, bm := range []struct {
+		name string
+		docs []any
+	}{
+		{"full", full},
+		{"projected", projected},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for b.Loop() {
+				cursor, err := mongo.NewCursorFromDocuments(bm.docs, nil, nil)
+				if err != nil {
+					b.Fatalf("NewCursorFromDocuments() returned error: %v", err)
+				}
+				if _, err := repo.decodeTasks(context.Background(), cursor); err != nil {
+					b.Fatalf("decodeTasks() returned error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// TestUpsertOutcome tests that duplicate key errors of a bulk upsert count as
+// conflicts and any other write error fails it
+func TestUpsertOutcome(t *testing.T) {
+	taskList := []*Task{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	res := &mongo.BulkWriteResult{UpsertedIDs: map[int64]any{0: taskList[0].ID}}
+	duplicate := mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{
+		{WriteError: mongo.WriteError{Index: 2, Code: 11000, Message: "E11000 duplicate key error"}},
+	}}
+
+	result, err := upsertOutcome(taskList, res, duplicate)
+	if err != nil {
+		t.Fatalf("upsertOutcome() returned error: %v", err)
+	}
+	want := &UpsertResult{
+		Inserted:  []uuid.UUID{taskList[0].ID},
+		Updated:   []uuid.UUID{taskList[1].ID},
+		Conflicts: []uuid.UUID{taskList[2].ID},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("expected %+v, got %+v", want, result)
+	}
+
+	failed := mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{
+		{WriteError: mongo.WriteError{Index: 1, Code: 121, Message: "Document failed validation"}},
+	}}
+	if _, err := upsertOutcome(taskList, res, failed); err == nil {
+		t.Error("expected an error for a non-duplicate write error")
+	}
+}
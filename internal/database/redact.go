@@ -0,0 +1,38 @@
+package database
+
+import "strings"
+
+const redacted = "xxxxx"
+
+// RedactURI hides the password in a connection URI, and the username too when
+// redactUsername is set, so the URI can be logged. Hosts, path and query are
+// kept. A URI without credentials is returned unchanged.
+func RedactURI(uri string, redactUsername bool) string {
+	scheme, rest, ok := strings.Cut(uri, "://")
+	if !ok {
+		return uri
+	}
+
+	authority, tail := rest, ""
+	if i := strings.IndexAny(rest, "/?"); i >= 0 {
+		authority, tail = rest[:i], rest[i:]
+	}
+
+	// Passwords may contain an unescaped "@", so the hosts start after the last one
+	at := strings.LastIndex(authority, "@")
+	if at < 0 {
+		return uri
+	}
+	userinfo, hosts := authority[:at], authority[at+1:]
+
+	username, _, hasPassword := strings.Cut(userinfo, ":")
+	if redactUsername {
+		username = redacted
+	}
+	userinfo = username
+	if hasPassword {
+		userinfo += ":" + redacted
+	}
+
+	return scheme + "://" + userinfo + "@" + hosts + tail
+}
@@ -0,0 +1,283 @@
+package database
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnknownTenant is returned when a request names a tenant with no shard
+// and there is no fallback database.
+var ErrUnknownTenant = stderrors.New("no database for tenant")
+
+type tenantKey struct{}
+
+// WithTenant returns a context whose repository calls are routed to tenant's shard.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set by WithTenant.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok && tenant != ""
+}
+
+// ShardedDatabase routes every repository call to one of several databases,
+// chosen by the tenant in the call's context. Tenants without a shard, and
+// calls without a tenant, use the fallback.
+type ShardedDatabase struct {
+	shards   map[string]Database
+	fallback Database
+	taskRepo *shardedTaskRepository
+}
+
+// NewShardedDatabase routes tenants to shards. fallback may be nil, in which
+// case calls for other tenants fail with ErrUnknownTenant.
+func NewShardedDatabase(shards map[string]Database, fallback Database) *ShardedDatabase {
+	db := &ShardedDatabase{
+		shards:   shards,
+		fallback: fallback,
+	}
+	db.taskRepo = &shardedTaskRepository{db: db}
+	return db
+}
+
+// ConnectShards maps each tenant in shards, keyed by tenant with its URI, to
+// a database, calling connect once per distinct URI. Tenants on fallbackURI
+// get fallback. Tenants sharing a database so share its client, cache and
+// limiter, and a write through one is seen by the others.
+func ConnectShards(shards map[string]string, fallbackURI string, fallback Database, connect func(uri string) (Database, error)) (map[string]Database, error) {
+	byURI := map[string]Database{fallbackURI: fallback}
+	dbs := make(map[string]Database, len(shards))
+	for tenant, uri := range shards {
+		db, ok := byURI[uri]
+		if !ok {
+			var err error
+			if db, err = connect(uri); err != nil {
+				return nil, fmt.Errorf("tenant %s: %w", tenant, err)
+			}
+			byURI[uri] = db
+		}
+		dbs[tenant] = db
+	}
+	return dbs, nil
+}
+
+// route picks the database for the tenant in ctx.
+func (d *ShardedDatabase) route(ctx context.Context) (Database, error) {
+	tenant, ok := TenantFromContext(ctx)
+	if ok {
+		if shard, exists := d.shards[tenant]; exists {
+			return shard, nil
+		}
+	}
+	if d.fallback == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownTenant, tenant)
+	}
+	return d.fallback, nil
+}
+
+// all returns every distinct database, shards first. Tenants may share a
+// database, with each other or with the fallback, so it is listed once and
+// never disconnected or counted twice.
+func (d *ShardedDatabase) all() []Database {
+	dbs := make([]Database, 0, len(d.shards)+1)
+	for _, shard := range d.shards {
+		if !slices.Contains(dbs, shard) {
+			dbs = append(dbs, shard)
+		}
+	}
+	if d.fallback != nil && !slices.Contains(dbs, d.fallback) {
+		dbs = append(dbs, d.fallback)
+	}
+	return dbs
+}
+
+// Ping succeeds only if every shard answers.
+func (d *ShardedDatabase) Ping(ctx context.Context) error {
+	for _, db := range d.all() {
+		if err := db.Ping(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *ShardedDatabase) Disconnect(ctx context.Context) error {
+	var errs []error
+	for _, db := range d.all() {
+		errs = append(errs, db.Disconnect(ctx))
+	}
+	return stderrors.Join(errs...)
+}
+
+func (d *ShardedDatabase) GetTaskRepository() TaskRepository {
+	return d.taskRepo
+}
+
+// CacheStats sums the cache counters of the shards that have a cache.
+func (d *ShardedDatabase) CacheStats() (stats CacheStats, ok bool) {
+	for _, db := range d.all() {
+		source, isSource := db.(interface{ CacheStats() (CacheStats, bool) })
+		if !isSource {
+			continue
+		}
+		if shardStats, enabled := source.CacheStats(); enabled {
+			stats.Hits += shardStats.Hits
+			stats.Misses += shardStats.Misses
+			stats.Entries += shardStats.Entries
+			ok = true
+		}
+	}
+	return stats, ok
+}
+
+// shardedTaskRepository resolves the shard on every call, since the tenant
+// lives in the call's context rather than in the repository.
+type shardedTaskRepository struct {
+	db *ShardedDatabase
+}
+
+func (r *shardedTaskRepository) repo(ctx context.Context) (TaskRepository, error) {
+	db, err := r.db.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.GetTaskRepository(), nil
+}
+
+func (r *shardedTaskRepository) Create(ctx context.Context, task *Task) error {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.Create(ctx, task)
+}
+
+func (r *shardedTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*Task, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindByID(ctx, id)
+}
+
+func (r *shardedTaskRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return false, err
+	}
+	return repo.Exists(ctx, id)
+}
+
+func (r *shardedTaskRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*Task, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindByIDs(ctx, ids)
+}
+
+func (r *shardedTaskRepository) FindAll(ctx context.Context, query TaskQuery) ([]*Task, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindAll(ctx, query)
+}
+
+func (r *shardedTaskRepository) Stream(ctx context.Context, query TaskQuery, fn func(*Task) error) error {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.Stream(ctx, query, fn)
+}
+
+func (r *shardedTaskRepository) CountBy(ctx context.Context, field string, query TaskQuery) (map[string]int64, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.CountBy(ctx, field, query)
+}
+
+func (r *shardedTaskRepository) CountCompletedByDay(ctx context.Context, from, to int64, loc *time.Location) (map[string]int64, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.CountCompletedByDay(ctx, from, to, loc)
+}
+
+func (r *shardedTaskRepository) FindOneAndUpdate(ctx context.Context, id uuid.UUID, update TaskUpdate) (*Task, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindOneAndUpdate(ctx, id, update)
+}
+
+func (r *shardedTaskRepository) UpdateMany(ctx context.Context, updates map[uuid.UUID]TaskUpdate) ([]uuid.UUID, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.UpdateMany(ctx, updates)
+}
+
+func (r *shardedTaskRepository) UpsertMany(ctx context.Context, tasks []*Task) (*UpsertResult, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.UpsertMany(ctx, tasks)
+}
+
+func (r *shardedTaskRepository) CreateMany(ctx context.Context, tasks []*Task) error {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.CreateMany(ctx, tasks)
+}
+
+func (r *shardedTaskRepository) DeleteMany(ctx context.Context, query TaskQuery) (int64, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return repo.DeleteMany(ctx, query)
+}
+
+func (r *shardedTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.Delete(ctx, id)
+}
+
+// HealthCheck checks the tenant's shard, or every shard when the context has
+// no tenant, as for readiness probes.
+func (r *shardedTaskRepository) HealthCheck(ctx context.Context) error {
+	if _, ok := TenantFromContext(ctx); ok {
+		repo, err := r.repo(ctx)
+		if err != nil {
+			return err
+		}
+		return repo.HealthCheck(ctx)
+	}
+
+	for _, db := range r.db.all() {
+		if err := db.GetTaskRepository().HealthCheck(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
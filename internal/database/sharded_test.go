@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// staticDatabase implements Database around a fixed repository
+type staticDatabase struct {
+	repo        TaskRepository
+	pingErr     error
+	disconnects int
+}
+
+func (d *staticDatabase) Ping(ctx context.Context) error { return d.pingErr }
+func (d *staticDatabase) Disconnect(ctx context.Context) error {
+	d.disconnects++
+	return nil
+}
+func (d *staticDatabase) GetTaskRepository() TaskRepository { return d.repo }
+
+// TestShardedDatabaseRouting tests that calls reach the shard of the tenant in the context
+func TestShardedDatabaseRouting(t *testing.T) {
+	id := uuid.New()
+	shard := func(title string) *staticDatabase {
+		repo := newMapRepository(id)
+		repo.tasks[id] = Task{ID: id, Title: title}
+		return &staticDatabase{repo: repo}
+	}
+
+	db := NewShardedDatabase(map[string]Database{
+		"acme":   shard("acme"),
+		"globex": shard("globex"),
+	}, shard("default"))
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantTitle string
+	}{
+		{"acme", WithTenant(context.Background(), "acme"), "acme"},
+		{"globex", WithTenant(context.Background(), "globex"), "globex"},
+		{"unknown tenant", WithTenant(context.Background(), "initech"), "default"},
+		{"no tenant", context.Background(), "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := db.GetTaskRepository().FindByID(tt.ctx, id)
+			if err != nil {
+				t.Fatalf("FindByID() returned error: %v", err)
+			}
+			if task.Title != tt.wantTitle {
+				t.Errorf("expected the %s shard, got %s", tt.wantTitle, task.Title)
+			}
+		})
+	}
+}
+
+// TestShardedDatabaseNoFallback tests that unknown tenants fail without a fallback
+func TestShardedDatabaseNoFallback(t *testing.T) {
+	db := NewShardedDatabase(map[string]Database{
+		"acme": &staticDatabase{repo: newMapRepository()},
+	}, nil)
+
+	ctx := WithTenant(context.Background(), "initech")
+	if _, err := db.GetTaskRepository().FindByID(ctx, uuid.New()); !stderrors.Is(err, ErrUnknownTenant) {
+		t.Errorf("expected ErrUnknownTenant, got %v", err)
+	}
+}
+
+// TestShardedDatabasePing tests that one unreachable shard fails the ping
+func TestShardedDatabasePing(t *testing.T) {
+	down := stderrors.New("connection refused")
+	db := NewShardedDatabase(map[string]Database{
+		"acme":   &staticDatabase{},
+		"globex": &staticDatabase{pingErr: down},
+	}, &staticDatabase{})
+
+	if err := db.Ping(context.Background()); !stderrors.Is(err, down) {
+		t.Errorf("expected the shard's ping error, got %v", err)
+	}
+}
+
+// TestShardedDatabaseSharedShard tests that a database shared by several
+// tenants and the fallback is disconnected once
+func TestShardedDatabaseSharedShard(t *testing.T) {
+	shared := &staticDatabase{}
+	other := &staticDatabase{}
+	db := NewShardedDatabase(map[string]Database{
+		"acme":   shared,
+		"globex": shared,
+		"hooli":  other,
+	}, shared)
+
+	if got := db.all(); len(got) != 2 {
+		t.Errorf("expected 2 distinct databases, got %d", len(got))
+	}
+
+	if err := db.Disconnect(context.Background()); err != nil {
+		t.Fatalf("Disconnect() returned error: %v", err)
+	}
+	if shared.disconnects != 1 || other.disconnects != 1 {
+		t.Errorf("expected each database disconnected once, got %d and %d", shared.disconnects, other.disconnects)
+	}
+}
+
+// TestConnectShards tests that tenants on one URI share a database, and that
+// tenants on the fallback's URI get the fallback
+func TestConnectShards(t *testing.T) {
+	fallback := &staticDatabase{}
+	connects := 0
+	connect := func(uri string) (Database, error) {
+		connects++
+		return &staticDatabase{}, nil
+	}
+
+	shards, err := ConnectShards(map[string]string{
+		"acme":    "mongodb://shared",
+		"globex":  "mongodb://shared",
+		"initech": "mongodb://default",
+	}, "mongodb://default", fallback, connect)
+	if err != nil {
+		t.Fatalf("ConnectShards() returned error: %v", err)
+	}
+
+	if connects != 1 {
+		t.Errorf("expected one connection, got %d", connects)
+	}
+	if shards["acme"] != shards["globex"] {
+		t.Error("expected tenants on one URI to share a database")
+	}
+	if shards["initech"] != fallback {
+		t.Error("expected a tenant on the default URI to get the fallback")
+	}
+}
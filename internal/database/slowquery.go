@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// slowQueryRepository decorates a TaskRepository, logging a warning for every
+// operation that takes longer than threshold.
+type slowQueryRepository struct {
+	next      TaskRepository
+	threshold time.Duration
+	logger    *slog.Logger
+}
+
+// NewSlowQueryRepository wraps repo with slow-operation logging. A threshold
+// of zero or less returns repo unchanged.
+func NewSlowQueryRepository(repo TaskRepository, threshold time.Duration, logger *slog.Logger) TaskRepository {
+	if threshold <= 0 {
+		return repo
+	}
+	return &slowQueryRepository{
+		next:      repo,
+		threshold: threshold,
+		logger:    logger,
+	}
+}
+
+func (r *slowQueryRepository) observe(operation string, start time.Time) {
+	if elapsed := time.Since(start); elapsed > r.threshold {
+		r.logger.Warn("Slow repository operation",
+			"operation", operation,
+			"duration", elapsed,
+			"threshold", r.threshold,
+		)
+	}
+}
+
+func (r *slowQueryRepository) Create(ctx context.Context, task *Task) error {
+	defer r.observe("Create", time.Now())
+	return r.next.Create(ctx, task)
+}
+
+func (r *slowQueryRepository) FindByID(ctx context.Context, id uuid.UUID) (*Task, error) {
+	defer r.observe("FindByID", time.Now())
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *slowQueryRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	defer r.observe("Exists", time.Now())
+	return r.next.Exists(ctx, id)
+}
+
+func (r *slowQueryRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*Task, error) {
+	defer r.observe("FindByIDs", time.Now())
+	return r.next.FindByIDs(ctx, ids)
+}
+
+func (r *slowQueryRepository) FindAll(ctx context.Context, query TaskQuery) ([]*Task, error) {
+	defer r.observe("FindAll", time.Now())
+	return r.next.FindAll(ctx, query)
+}
+
+func (r *slowQueryRepository) Stream(ctx context.Context, query TaskQuery, fn func(*Task) error) error {
+	defer r.observe("Stream", time.Now())
+	return r.next.Stream(ctx, query, fn)
+}
+
+func (r *slowQueryRepository) FindOneAndUpdate(ctx context.Context, id uuid.UUID, update TaskUpdate) (*Task, error) {
+	defer r.observe("FindOneAndUpdate", time.Now())
+	return r.next.FindOneAndUpdate(ctx, id, update)
+}
+
+func (r *slowQueryRepository) CountCompletedByDay(ctx context.Context, from, to int64, loc *time.Location) (map[string]int64, error) {
+	defer r.observe("CountCompletedByDay", time.Now())
+	return r.next.CountCompletedByDay(ctx, from, to, loc)
+}
+
+func (r *slowQueryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.observe("Delete", time.Now())
+	return r.next.Delete(ctx, id)
+}
+
+func (r *slowQueryRepository) CountBy(ctx context.Context, field string, query TaskQuery) (map[string]int64, error) {
+	defer r.observe("CountBy", time.Now())
+	return r.next.CountBy(ctx, field, query)
+}
+
+func (r *slowQueryRepository) UpdateMany(ctx context.Context, updates map[uuid.UUID]TaskUpdate) ([]uuid.UUID, error) {
+	defer r.observe("UpdateMany", time.Now())
+	return r.next.UpdateMany(ctx, updates)
+}
+
+func (r *slowQueryRepository) UpsertMany(ctx context.Context, tasks []*Task) (*UpsertResult, error) {
+	defer r.observe("UpsertMany", time.Now())
+	return r.next.UpsertMany(ctx, tasks)
+}
+
+func (r *slowQueryRepository) CreateMany(ctx context.Context, tasks []*Task) error {
+	defer r.observe("CreateMany", time.Now())
+	return r.next.CreateMany(ctx, tasks)
+}
+
+func (r *slowQueryRepository) DeleteMany(ctx context.Context, query TaskQuery) (int64, error) {
+	defer r.observe("DeleteMany", time.Now())
+	return r.next.DeleteMany(ctx, query)
+}
+
+func (r *slowQueryRepository) HealthCheck(ctx context.Context) error {
+	defer r.observe("HealthCheck", time.Now())
+	return r.next.HealthCheck(ctx)
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sleepyRepository implements TaskRepository; only FindAll may be called
+type sleepyRepository struct {
+	TaskRepository
+	delay time.Duration
+}
+
+func (r *sleepyRepository) FindAll(ctx context.Context, query TaskQuery) ([]*Task, error) {
+	time.Sleep(r.delay)
+	return nil, nil
+}
+
+// TestSlowQueryRepository tests that only operations over the threshold are logged
+func TestSlowQueryRepository(t *testing.T) {
+	tests := []struct {
+		name    string
+		delay   time.Duration
+		wantLog bool
+	}{
+		{"fast operation", 0, false},
+		{"slow operation", 30 * time.Millisecond, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+			repo := NewSlowQueryRepository(&sleepyRepository{delay: tt.delay}, 10*time.Millisecond, logger)
+			repo.FindAll(context.Background(), TaskQuery{})
+
+			logged := strings.Contains(logs.String(), "operation=FindAll")
+			if logged != tt.wantLog {
+				t.Errorf("expected log=%v, got %q", tt.wantLog, logs.String())
+			}
+		})
+	}
+}
+
+// TestSlowQueryRepositoryDisabled tests that a zero threshold skips the decorator
+func TestSlowQueryRepositoryDisabled(t *testing.T) {
+	inner := &sleepyRepository{}
+
+	if repo := NewSlowQueryRepository(inner, 0, slog.Default()); repo != inner {
+		t.Error("expected the repository to be returned unwrapped")
+	}
+}
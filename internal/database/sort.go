@@ -0,0 +1,47 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sortFields is the allowlist of fields a sort may name, by their API name.
+var sortFields = map[string]string{
+	"createdAt": "createdAt",
+	"updatedAt": "updatedAt",
+	"title":     "title",
+}
+
+// maxSortKeys bounds a sort; ties after the last key are broken by ID anyway.
+const maxSortKeys = 3
+
+// ParseSort parses a comma-separated list of field names, each ordered
+// ascending or, prefixed with -, descending. For example "-createdAt" is
+// newest first and "title,-updatedAt" is by title, then most recently updated
+// first. The fields are createdAt, updatedAt and title, each at most once.
+func ParseSort(sort string) ([]SortKey, error) {
+	names := strings.Split(sort, ",")
+	if len(names) > maxSortKeys {
+		return nil, fmt.Errorf("sort has more than %d fields", maxSortKeys)
+	}
+
+	keys := make([]SortKey, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		descending := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+
+		field, ok := sortFields[name]
+		if !ok {
+			return nil, fmt.Errorf("cannot sort by %q", name)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("sort names %q more than once", name)
+		}
+		seen[field] = true
+
+		keys = append(keys, SortKey{Field: field, Descending: descending})
+	}
+	return keys, nil
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseSort tests parsing of valid sorts into sort keys
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		sort string
+		want []SortKey
+	}{
+		{"createdAt", []SortKey{{Field: "createdAt"}}},
+		{"-createdAt", []SortKey{{Field: "createdAt", Descending: true}}},
+		{"title, -updatedAt", []SortKey{{Field: "title"}, {Field: "updatedAt", Descending: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			got, err := ParseSort(tt.sort)
+			if err != nil {
+				t.Fatalf("ParseSort() returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestParseSortRejects tests that unknown, repeated and empty fields never parse
+func TestParseSortRejects(t *testing.T) {
+	for _, sort := range []string{"", "-", "priority", "createdAt,-createdAt", "title,,createdAt", "completedAt", "createdAt,updatedAt,title,createdAt"} {
+		t.Run(sort, func(t *testing.T) {
+			if got, err := ParseSort(sort); err == nil {
+				t.Errorf("expected an error, got %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package database
+
+import "context"
+
+// StreamAll delivers every stored task, archived ones included, on the
+// returned channel for background jobs that must not load them all at once.
+// The task channel is closed once the tasks run out, the repository fails or
+// ctx is canceled; the error channel then yields the failure, ctx's error
+// after a cancellation, and is closed, so receiving from it gives nil after a
+// complete run. The caller must drain the task channel or cancel ctx.
+func StreamAll(ctx context.Context, repo TaskRepository) (<-chan *Task, <-chan error) {
+	taskCh := make(chan *Task)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+		defer close(taskCh)
+
+		err := repo.Stream(ctx, TaskQuery{}, func(task *Task) error {
+			select {
+			case taskCh <- task:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return taskCh, errCh
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// sliceRepository implements Stream over a fixed list of tasks; no other
+// method may be called
+type sliceRepository struct {
+	TaskRepository
+	tasks []*Task
+}
+
+func (r *sliceRepository) Stream(ctx context.Context, query TaskQuery, fn func(*Task) error) error {
+	for _, task := range r.tasks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newSliceRepository(n int) *sliceRepository {
+	repo := &sliceRepository{}
+	for range n {
+		repo.tasks = append(repo.tasks, &Task{ID: uuid.New(), Title: "Streamed"})
+	}
+	return repo
+}
+
+// TestStreamAll tests that every task is delivered before both channels close
+func TestStreamAll(t *testing.T) {
+	repo := newSliceRepository(5)
+
+	taskCh, errCh := StreamAll(context.Background(), repo)
+
+	var got []*Task
+	for task := range taskCh {
+		got = append(got, task)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !slices.Equal(got, repo.tasks) {
+		t.Errorf("expected the %d stored tasks, got %d", len(repo.tasks), len(got))
+	}
+}
+
+// TestStreamAllCanceled tests that canceling mid-stream closes the channel
+// and reports the cancellation
+func TestStreamAllCanceled(t *testing.T) {
+	repo := newSliceRepository(5)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	taskCh, errCh := StreamAll(ctx, repo)
+
+	if task := <-taskCh; task != repo.tasks[0] {
+		t.Fatalf("expected the first task, got %+v", task)
+	}
+	cancel()
+
+	// Nothing receives, so the stream can only stop
+	if err := <-errCh; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if task, ok := <-taskCh; ok {
+		t.Errorf("expected the task channel closed, got %+v", task)
+	}
+}
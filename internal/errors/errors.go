@@ -13,10 +13,70 @@ const (
 	ErrorTypeBadRequest   ErrorType = "BAD_REQUEST"
 	ErrorTypeInternal     ErrorType = "INTERNAL_ERROR"
 	ErrorTypeUnauthorized ErrorType = "UNAUTHORIZED"
+	ErrorTypeForbidden    ErrorType = "FORBIDDEN"
+	ErrorTypeTimeout      ErrorType = "TIMEOUT"
+	ErrorTypeUnavailable  ErrorType = "SERVICE_UNAVAILABLE"
+	ErrorTypeMethod       ErrorType = "METHOD_NOT_ALLOWED"
+	ErrorTypeConflict     ErrorType = "CONFLICT"
+	ErrorTypeMediaType    ErrorType = "UNSUPPORTED_MEDIA_TYPE"
+	ErrorTypeNotAccepted  ErrorType = "NOT_ACCEPTABLE"
+	ErrorTypeDependency   ErrorType = "FAILED_DEPENDENCY"
+	ErrorTypePrecondition ErrorType = "PRECONDITION_FAILED"
+)
+
+// ErrorCode identifies a specific failure within an ErrorType. Codes are part
+// of the API contract: clients switch on them, so never rename one.
+type ErrorCode string
+
+const (
+	CodeInvalidTaskID          ErrorCode = "TASK_ID_INVALID"
+	CodeTaskNotFound           ErrorCode = "TASK_NOT_FOUND"
+	CodeNoAdjacentTask         ErrorCode = "NO_ADJACENT_TASK"
+	CodeTitleRequired          ErrorCode = "TASK_TITLE_REQUIRED"
+	CodeTitleTooLong           ErrorCode = "TASK_TITLE_TOO_LONG"
+	CodeDescriptionTooLong     ErrorCode = "TASK_DESCRIPTION_TOO_LONG"
+	CodeDescriptionRequired    ErrorCode = "TASK_DESCRIPTION_REQUIRED"
+	CodeControlCharacters      ErrorCode = "TASK_CONTROL_CHARACTERS"
+	CodeExpiryInvalid          ErrorCode = "TASK_EXPIRY_INVALID"
+	CodeAssigneeInvalid        ErrorCode = "TASK_ASSIGNEE_INVALID"
+	CodeBlockerNotFound        ErrorCode = "TASK_BLOCKER_NOT_FOUND"
+	CodeBlockerCycle           ErrorCode = "TASK_BLOCKER_CYCLE"
+	CodeTaskBlocked            ErrorCode = "TASK_BLOCKED"
+	CodeLookupIDsInvalid       ErrorCode = "LOOKUP_IDS_INVALID"
+	CodeBatchInvalid           ErrorCode = "BATCH_UPDATES_INVALID"
+	CodeBatchDuplicateID       ErrorCode = "BATCH_DUPLICATE_ID"
+	CodeBatchNotApplied        ErrorCode = "BATCH_ITEM_NOT_APPLIED"
+	CodeValidationFailed       ErrorCode = "VALIDATION_FAILED"
+	CodeVersionConflict        ErrorCode = "TASK_VERSION_CONFLICT"
+	CodeSyncConflict           ErrorCode = "SYNC_CONFLICT"
+	CodeSyncVersionInvalid     ErrorCode = "SYNC_VERSION_INVALID"
+	CodeTaskModified           ErrorCode = "TASK_MODIFIED"
+	CodeInvalidJSON            ErrorCode = "INVALID_JSON"
+	CodeUnreadableBody         ErrorCode = "REQUEST_BODY_UNREADABLE"
+	CodeBodyRequired           ErrorCode = "REQUEST_BODY_REQUIRED"
+	CodeInvalidQuery           ErrorCode = "INVALID_QUERY_PARAMETER"
+	CodeInvalidPatch           ErrorCode = "INVALID_PATCH"
+	CodeUnsupportedContentType ErrorCode = "UNSUPPORTED_CONTENT_TYPE"
+	CodeUnsupportedEncoding    ErrorCode = "UNSUPPORTED_CONTENT_ENCODING"
+	CodeUnsupportedAPIVersion  ErrorCode = "API_VERSION_UNSUPPORTED"
+	CodeNotAcceptable          ErrorCode = "RESPONSE_TYPE_NOT_ACCEPTABLE"
+	CodeSeedDisabled           ErrorCode = "SEED_DISABLED"
+	CodeAdminTokenInvalid      ErrorCode = "ADMIN_TOKEN_INVALID"
+	CodeRouteNotFound          ErrorCode = "ROUTE_NOT_FOUND"
+	CodeMethodNotAllowed       ErrorCode = "METHOD_NOT_ALLOWED"
+	CodeRequestTimeout         ErrorCode = "REQUEST_TIMEOUT"
+	CodeStorageFailure         ErrorCode = "STORAGE_FAILURE"
+	CodeEncodingFailure        ErrorCode = "ENCODING_FAILURE"
+	CodeDatabaseUnreachable    ErrorCode = "DATABASE_UNREACHABLE"
+	CodeStorageUnavailable     ErrorCode = "STORAGE_UNAVAILABLE"
+	CodeStorageBusy            ErrorCode = "STORAGE_BUSY"
+	CodeShuttingDown           ErrorCode = "SHUTTING_DOWN"
+	CodeUnexpected             ErrorCode = "UNEXPECTED_ERROR"
 )
 
 type APIError struct {
 	Type    ErrorType `json:"type"`
+	Code    ErrorCode `json:"code,omitempty"`
 	Message string    `json:"message"`
 	Details any       `json:"details,omitempty"`
 }
@@ -25,6 +85,13 @@ func (e *APIError) Error() string {
 	return e.Message
 }
 
+// WithCode sets the specific error code and returns e, so it chains onto a
+// constructor: NewNotFoundError("Task not found").WithCode(CodeTaskNotFound).
+func (e *APIError) WithCode(code ErrorCode) *APIError {
+	e.Code = code
+	return e
+}
+
 func NewValidationError(message string, details any) *APIError {
 	return &APIError{
 		Type:    ErrorTypeValidation,
@@ -54,6 +121,69 @@ func NewInternalError(message string) *APIError {
 	}
 }
 
+func NewUnauthorizedError(message string) *APIError {
+	return &APIError{
+		Type:    ErrorTypeUnauthorized,
+		Message: message,
+	}
+}
+
+func NewForbiddenError(message string) *APIError {
+	return &APIError{
+		Type:    ErrorTypeForbidden,
+		Message: message,
+	}
+}
+
+func NewTimeoutError(message string) *APIError {
+	return &APIError{
+		Type:    ErrorTypeTimeout,
+		Message: message,
+	}
+}
+
+func NewUnavailableError(message string) *APIError {
+	return &APIError{
+		Type:    ErrorTypeUnavailable,
+		Message: message,
+	}
+}
+
+func NewMethodNotAllowedError(message string) *APIError {
+	return &APIError{
+		Type:    ErrorTypeMethod,
+		Message: message,
+	}
+}
+
+func NewConflictError(message string) *APIError {
+	return &APIError{
+		Type:    ErrorTypeConflict,
+		Message: message,
+	}
+}
+
+func NewUnsupportedMediaTypeError(message string) *APIError {
+	return &APIError{
+		Type:    ErrorTypeMediaType,
+		Message: message,
+	}
+}
+
+func NewNotAcceptableError(message string) *APIError {
+	return &APIError{
+		Type:    ErrorTypeNotAccepted,
+		Message: message,
+	}
+}
+
+func NewFailedDependencyError(message string) *APIError {
+	return &APIError{
+		Type:    ErrorTypeDependency,
+		Message: message,
+	}
+}
+
 func RespondWithError(w http.ResponseWriter, statusCode int, err *APIError) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -64,3 +194,17 @@ type ValidationErrorDetail struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 }
+
+type JSONErrorDetail struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+func NewPreconditionFailedError(message string) *APIError {
+	return &APIError{
+		Type:    ErrorTypePrecondition,
+		Message: message,
+	}
+}
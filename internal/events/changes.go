@@ -0,0 +1,80 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/google/uuid"
+)
+
+// FieldChange is one field an update changed, by its API name. From and To
+// hold the values as they appear in JSON, nil for an unset field.
+type FieldChange struct {
+	Field string
+	From  any
+	To    any
+}
+
+// String describes the change for people, as in `title: "a" -> "b"`.
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Field, changeValue(c.From), changeValue(c.To))
+}
+
+func changeValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// Diff lists the fields a client can change that differ between before and
+// after, in a fixed order. Bookkeeping fields such as updatedAt and
+// completedAt, which only follow the others, are left out.
+func Diff(before, after *database.Task) []FieldChange {
+	var changes []FieldChange
+	compare := func(field string, from, to any) {
+		if from != to {
+			changes = append(changes, FieldChange{Field: field, From: from, To: to})
+		}
+	}
+
+	compare("title", before.Title, after.Title)
+	compare("description", before.Description, after.Description)
+	compare("completed", before.Completed, after.Completed)
+	compare("assigneeId", optionalString(before.AssigneeID), optionalString(after.AssigneeID))
+	compare("archived", before.Archived, after.Archived)
+	compare("expiresAt", optionalTime(before.ExpiresAt), optionalTime(after.ExpiresAt))
+	if !slices.Equal(before.BlockedBy, after.BlockedBy) {
+		changes = append(changes, FieldChange{Field: "blockedBy", From: idStrings(before.BlockedBy), To: idStrings(after.BlockedBy)})
+	}
+
+	return changes
+}
+
+// optionalString gives nil for an unset string, so it compares and prints
+// as null.
+func optionalString(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func optionalTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func idStrings(ids []uuid.UUID) []string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
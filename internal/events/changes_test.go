@@ -0,0 +1,63 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/google/uuid"
+)
+
+// TestDiff tests that only the changed client fields are listed, in order
+func TestDiff(t *testing.T) {
+	alice := "alice"
+	expiresAt := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	blocker := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	completedAt := int64(200)
+
+	before := &database.Task{Title: "a", Description: "same", UpdatedAt: 100}
+	after := &database.Task{
+		Title:       "b",
+		Description: "same",
+		Completed:   true,
+		CompletedAt: &completedAt,
+		AssigneeID:  &alice,
+		ExpiresAt:   &expiresAt,
+		BlockedBy:   []uuid.UUID{blocker},
+		UpdatedAt:   200,
+	}
+
+	want := []FieldChange{
+		{Field: "title", From: "a", To: "b"},
+		{Field: "completed", From: false, To: true},
+		{Field: "assigneeId", From: nil, To: "alice"},
+		{Field: "expiresAt", From: nil, To: "2025-11-20T00:00:00Z"},
+		{Field: "blockedBy", From: []string{}, To: []string{blocker.String()}},
+	}
+	if got := Diff(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := Diff(after, after); got != nil {
+		t.Errorf("expected no changes between equal tasks, got %v", got)
+	}
+}
+
+// TestFieldChangeString tests the human-readable form of a change
+func TestFieldChangeString(t *testing.T) {
+	tests := []struct {
+		change FieldChange
+		want   string
+	}{
+		{FieldChange{Field: "title", From: "a", To: "b"}, `title: "a" -> "b"`},
+		{FieldChange{Field: "completed", From: false, To: true}, `completed: false -> true`},
+		{FieldChange{Field: "assigneeId", From: "alice", To: nil}, `assigneeId: "alice" -> null`},
+	}
+
+	for _, tt := range tests {
+		if got := tt.change.String(); got != tt.want {
+			t.Errorf("expected %s, got %s", tt.want, got)
+		}
+	}
+}
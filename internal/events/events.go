@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	TaskCreated EventType = "task.created"
+	TaskUpdated EventType = "task.updated"
+	TaskDeleted EventType = "task.deleted"
+)
+
+// TaskEvent describes a change that has already been persisted.
+type TaskEvent struct {
+	Type       EventType
+	TaskID     uuid.UUID
+	Task       *database.Task // state after the change; nil for deletions
+	Changes    []FieldChange  // fields an update changed; nil when not known
+	OccurredAt time.Time
+}
+
+// TaskEventPublisher delivers task events to a sink. Publish is called after
+// the write succeeded and must not block the request for long; sinks own their
+// error handling since the change cannot be rolled back.
+type TaskEventPublisher interface {
+	Publish(ctx context.Context, event TaskEvent)
+}
+
+// NopPublisher discards every event.
+type NopPublisher struct{}
+
+func (NopPublisher) Publish(ctx context.Context, event TaskEvent) {}
+
+// LogPublisher writes every event to a structured log.
+type LogPublisher struct {
+	logger *slog.Logger
+}
+
+func NewLogPublisher(logger *slog.Logger) *LogPublisher {
+	return &LogPublisher{logger: logger}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, event TaskEvent) {
+	if len(event.Changes) == 0 {
+		p.logger.InfoContext(ctx, "Task event", "type", event.Type, "task_id", event.TaskID)
+		return
+	}
+
+	changes := make([]string, len(event.Changes))
+	for i, change := range event.Changes {
+		changes[i] = change.String()
+	}
+	p.logger.InfoContext(ctx, "Task event", "type", event.Type, "task_id", event.TaskID, "changes", changes)
+}
+
+// MultiPublisher fans each event out to all of its publishers in order.
+type MultiPublisher []TaskEventPublisher
+
+func (m MultiPublisher) Publish(ctx context.Context, event TaskEvent) {
+	for _, p := range m {
+		p.Publish(ctx, event)
+	}
+}
@@ -0,0 +1,33 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// ChangeNotifier is a publisher that wakes everyone waiting for the next
+// change. It carries no event data: waiters are expected to re-read what they
+// are interested in. Only changes made through this process are seen.
+type ChangeNotifier struct {
+	mu      sync.Mutex
+	changed chan struct{}
+}
+
+func NewChangeNotifier() *ChangeNotifier {
+	return &ChangeNotifier{changed: make(chan struct{})}
+}
+
+// Changed returns a channel that is closed by the next published event. Take
+// it before reading current state, so a change in between is not missed.
+func (n *ChangeNotifier) Changed() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.changed
+}
+
+func (n *ChangeNotifier) Publish(ctx context.Context, event TaskEvent) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	close(n.changed)
+	n.changed = make(chan struct{})
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// Next handles GET /api/v1/tasks/{id}/next
+func (h *TaskHandler) Next(w http.ResponseWriter, r *http.Request) {
+	h.adjacent(w, r, true)
+}
+
+// Prev handles GET /api/v1/tasks/{id}/prev
+func (h *TaskHandler) Prev(w http.ResponseWriter, r *http.Request) {
+	h.adjacent(w, r, false)
+}
+
+// adjacent returns the task right after (or before) the one in the path, in
+// createdAt order with ties broken by ID, whatever DEFAULT_SORT says. The list
+// filters apply, so navigation never leaves the list the client is looking
+// at; sort is rejected rather than ignored.
+func (h *TaskHandler) adjacent(w http.ResponseWriter, r *http.Request, next bool) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid task ID format for navigation", "id", idStr)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
+	}
+
+	// Positions only exist in createdAt order
+	if r.URL.Query().Has("sort") {
+		h.logger.Warn("Sorted navigation requested", "query", r.URL.RawQuery)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Navigation always follows createdAt order; sort is not supported").WithCode(errors.CodeInvalidQuery))
+		return
+	}
+
+	query, apiErr := h.parseTaskQuery(r)
+	if apiErr != nil {
+		h.logger.Warn("Invalid navigation query", "error", apiErr.Message, "query", r.URL.RawQuery)
+		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
+		return
+	}
+
+	current, err := h.db.GetTaskRepository().FindByID(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to retrieve task for navigation", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to retrieve task")
+		return
+	}
+	if current == nil {
+		h.logger.Info("Task not found for navigation", "task_id", id)
+		errors.RespondWithError(w, http.StatusNotFound,
+			errors.NewNotFoundError("Task not found").WithCode(errors.CodeTaskNotFound))
+		return
+	}
+
+	position := &database.Position{CreatedAt: current.CreatedAt, ID: current.ID}
+	if next {
+		query.After = position
+		query.Sort = []database.SortKey{{Field: "createdAt"}}
+	} else {
+		query.Before = position
+		query.Sort = []database.SortKey{{Field: "createdAt", Descending: true}}
+	}
+	query.Limit = 1
+
+	h.logger.Info("Fetching adjacent task", "task_id", id, "next", next)
+
+	found, err := h.db.GetTaskRepository().FindAll(r.Context(), query)
+	if err != nil {
+		h.logger.Error("Failed to retrieve adjacent task", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to retrieve task")
+		return
+	}
+	if len(found) == 0 {
+		h.logger.Info("No adjacent task", "task_id", id, "next", next)
+		errors.RespondWithError(w, http.StatusNotFound,
+			errors.NewNotFoundError("No task in that direction").WithCode(errors.CodeNoAdjacentTask))
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, found[0], nil)
+}
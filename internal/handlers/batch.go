@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+
+	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
+	"github.com/PinceredCoder/restGo/internal/events"
+	"github.com/google/uuid"
+)
+
+// batchItemResult reports what happened to one item of a batch request.
+// Results are in request order; Status is the HTTP status the item would have
+// got as a request of its own.
+type batchItemResult struct {
+	Index  int              `json:"index"`
+	ID     string           `json:"id,omitempty"`
+	Status int              `json:"status"`
+	Task   json.RawMessage  `json:"task,omitempty"`
+	Error  *errors.APIError `json:"error,omitempty"`
+}
+
+func (res *batchItemResult) fail(status int, apiErr *errors.APIError) {
+	res.Status = status
+	res.Error = apiErr
+}
+
+// duplicateIDError rejects a batch item naming a task an earlier item did.
+func duplicateIDError() *errors.APIError {
+	return errors.NewValidationError("Validation failed", []errors.ValidationErrorDetail{{
+		Field:   "Id",
+		Message: "task appears more than once in the batch",
+	}}).WithCode(errors.CodeBatchDuplicateID)
+}
+
+type batchResponse struct {
+	Results []batchItemResult `json:"results"`
+}
+
+// PatchBatch handles PATCH /api/v1/tasks/batch. Each item changes only the
+// fields it names. By default every valid item is applied on its own; with
+// "atomic" set a single failure means nothing is written.
+func (h *TaskHandler) PatchBatch(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Warn("Failed to read batch patch request body", "error", err)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Failed to read request body").WithCode(errors.CodeUnreadableBody))
+		return
+	}
+
+	if isEmptyBody(data) {
+		h.logger.Warn("Empty request body for batch patch")
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Request body is required").WithCode(errors.CodeBodyRequired))
+		return
+	}
+
+	var req tasks.BatchPatchTasksRequest
+	if err := h.unmarshal(data, &req); err != nil {
+		h.logger.Warn("Invalid JSON format in batch patch request", "error", err)
+		errors.RespondWithError(w, http.StatusBadRequest, h.convertUnmarshalError(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.logger.Warn("Validation failed for batch patch request", "error", err)
+		apiErr := h.convertValidationError(err)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	h.logger.Info("Patching tasks in batch", "count", len(req.Updates), "atomic", req.Atomic)
+
+	results := make([]batchItemResult, len(req.Updates))
+	ids := make([]uuid.UUID, len(req.Updates))
+	lookup := make([]uuid.UUID, 0, len(req.Updates))
+	seen := make(map[uuid.UUID]bool, len(req.Updates))
+
+	for i, item := range req.Updates {
+		results[i] = batchItemResult{Index: i, ID: item.Id}
+
+		if err := item.Validate(); err != nil {
+			apiErr := h.convertValidationError(err)
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+
+		// Validate() has already checked the format
+		ids[i] = uuid.MustParse(item.Id)
+		if seen[ids[i]] {
+			results[i].fail(http.StatusUnprocessableEntity, duplicateIDError())
+			continue
+		}
+		seen[ids[i]] = true
+		lookup = append(lookup, ids[i])
+	}
+
+	found, err := h.db.GetTaskRepository().FindByIDs(r.Context(), lookup)
+	if err != nil {
+		h.logger.Error("Failed to retrieve tasks for batch patch", "error", err)
+		h.storageFailed(w, err, "Failed to retrieve tasks")
+		return
+	}
+
+	stored := make(map[uuid.UUID]*database.Task, len(found))
+	for _, task := range found {
+		stored[task.ID] = task
+	}
+
+	// The blockers of every task being completed, loaded in one round trip.
+	// Batch items cannot change blockers, so only open ones can reject them
+	var blockerIDs []uuid.UUID
+	for i, item := range req.Updates {
+		if task := stored[ids[i]]; task != nil && results[i].Error == nil && item.GetCompleted() {
+			blockerIDs = append(blockerIDs, task.BlockedBy...)
+		}
+	}
+	blockers, err := h.findTasks(r.Context(), blockerIDs)
+	if err != nil {
+		h.logger.Error("Failed to retrieve blockers for batch patch", "error", err)
+		h.storageFailed(w, err, "Failed to retrieve tasks")
+		return
+	}
+
+	now := h.clock.Now().Unix()
+	updates := make(map[uuid.UUID]database.TaskUpdate, len(req.Updates))
+	updated := make([]*database.Task, len(req.Updates))
+
+	for i, item := range req.Updates {
+		if results[i].Error != nil {
+			continue
+		}
+
+		task, exists := stored[ids[i]]
+		if !exists {
+			results[i].fail(http.StatusNotFound,
+				errors.NewNotFoundError("Task not found").WithCode(errors.CodeTaskNotFound))
+			continue
+		}
+
+		// Only the fields named are written, and only over the copy checked
+		// here, so a concurrent write is neither reverted nor overlooked
+		update := database.TaskUpdate{
+			Title:           item.Title,
+			Description:     item.Description,
+			Completed:       item.Completed,
+			UpdatedAt:       now,
+			UnmodifiedSince: &task.UpdatedAt,
+		}
+		changed := *task
+		update.Apply(&changed)
+
+		if apiErr := h.validateRequired(changed.Title, changed.Description); apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+		if apiErr := h.validateLengths(changed.Title, changed.Description); apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+		if apiErr := h.validateCharacters(changed.Title, changed.Description); apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+		if item.GetCompleted() {
+			if apiErr := blockedError(openBlockers(task.BlockedBy, blockers)); apiErr != nil {
+				results[i].fail(rejectionStatus(apiErr), apiErr)
+				continue
+			}
+		}
+
+		updates[ids[i]] = update
+		updated[i] = &changed
+	}
+
+	failed := slices.ContainsFunc(results, func(res batchItemResult) bool { return res.Error != nil })
+
+	switch {
+	case req.Atomic && failed:
+		skipUnapplied(results)
+		h.logger.Warn("Atomic batch patch rejected", "count", len(req.Updates))
+
+	case req.Atomic:
+		missed, err := h.db.GetTaskRepository().UpdateMany(r.Context(), updates)
+		if err != nil {
+			h.logger.Error("Failed to update tasks in database", "error", err)
+			h.storageFailed(w, err, "Failed to update tasks")
+			return
+		}
+		if len(missed) == 0 {
+			break
+		}
+
+		for i := range results {
+			if results[i].Error != nil || !slices.Contains(missed, ids[i]) {
+				continue
+			}
+			status, apiErr, err := h.missedError(r.Context(), ids[i], "Task was modified while the batch was applied")
+			if err != nil {
+				h.logger.Error("Failed to check task exists in database", "error", err, "task_id", ids[i])
+				h.storageFailed(w, err, "Failed to update tasks")
+				return
+			}
+			results[i].fail(status, apiErr)
+		}
+		skipUnapplied(results)
+		h.logger.Warn("Atomic batch patch missed modified tasks", "count", len(req.Updates), "missed", len(missed))
+
+	default:
+		for i := range updated {
+			if updated[i] == nil {
+				continue
+			}
+
+			stored, err := h.db.GetTaskRepository().FindOneAndUpdate(r.Context(), ids[i], updates[ids[i]])
+			if err == nil && stored == nil {
+				var status int
+				var apiErr *errors.APIError
+				status, apiErr, err = h.missedError(r.Context(), ids[i], "Task was modified while the batch was applied")
+				if err == nil {
+					results[i].fail(status, apiErr)
+					continue
+				}
+			}
+			if err != nil {
+				h.logger.Error("Failed to update task in database", "error", err, "task_id", ids[i])
+				results[i].fail(storageError(err, "Failed to update task"))
+				continue
+			}
+
+			task := *stored
+			updates[ids[i]].Apply(&task)
+			updated[i] = &task
+		}
+	}
+
+	h.writeBatch(w, r, events.TaskUpdated, http.StatusOK, results, updated)
+}
+
+// skipUnapplied fails the items of an atomic batch that were valid but not
+// written because another item failed.
+func skipUnapplied(results []batchItemResult) {
+	for i := range results {
+		if results[i].Error == nil {
+			results[i].fail(http.StatusFailedDependency,
+				errors.NewFailedDependencyError("Not applied because another update in the batch failed").WithCode(errors.CodeBatchNotApplied))
+		}
+	}
+}
+
+// writeBatch publishes eventType for every applied item and writes the
+// results: status when every item was applied, 207 Multi-Status otherwise.
+// applied holds the written task at each successful item's index.
+func (h *TaskHandler) writeBatch(w http.ResponseWriter, r *http.Request, eventType events.EventType, status int, results []batchItemResult, applied []*database.Task) {
+	count := 0
+	for i, task := range applied {
+		if task == nil || results[i].Error != nil {
+			continue
+		}
+
+		h.publish(r.Context(), eventType, task.ID, task)
+
+		taskData, err := h.marshalOptions(nil).Marshal(task.ToProto())
+		if err != nil {
+			h.encodingFailed(w, err)
+			return
+		}
+		results[i].ID = task.ID.String()
+		results[i].Status = status
+		results[i].Task = taskData
+		count++
+	}
+
+	h.logger.Info("Batch completed", "event", eventType, "applied", count, "failed", len(results)-count)
+
+	data, err := json.Marshal(batchResponse{Results: results})
+	if err != nil {
+		h.encodingFailed(w, err)
+		return
+	}
+
+	if count < len(results) {
+		status = http.StatusMultiStatus
+	}
+	h.write(w, status, data)
+}
+
+// CreateBatch handles POST /api/v1/tasks/batch. Like PatchBatch, valid items
+// are created on their own by default, and "atomic" creates all or none.
+func (h *TaskHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Warn("Failed to read batch create request body", "error", err)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Failed to read request body").WithCode(errors.CodeUnreadableBody))
+		return
+	}
+
+	if isEmptyBody(data) {
+		h.logger.Warn("Empty request body for batch create")
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Request body is required").WithCode(errors.CodeBodyRequired))
+		return
+	}
+
+	var req tasks.BatchCreateTasksRequest
+	if err := h.unmarshal(data, &req); err != nil {
+		h.logger.Warn("Invalid JSON format in batch create request", "error", err)
+		errors.RespondWithError(w, http.StatusBadRequest, h.convertUnmarshalError(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.logger.Warn("Validation failed for batch create request", "error", err)
+		apiErr := h.convertValidationError(err)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	h.logger.Info("Creating tasks in batch", "count", len(req.Tasks), "atomic", req.Atomic)
+
+	now := h.clock.Now().Unix()
+	results := make([]batchItemResult, len(req.Tasks))
+	created := make([]*database.Task, len(req.Tasks))
+
+	for i, item := range req.Tasks {
+		results[i] = batchItemResult{Index: i}
+
+		if err := item.Validate(); err != nil {
+			apiErr := h.convertValidationError(err)
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+		if apiErr := h.validateRequired(item.Title, item.Description); apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+		if apiErr := h.validateLengths(item.Title, item.Description); apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+		if apiErr := h.validateCharacters(item.Title, item.Description); apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+		expiresAt, apiErr := h.validateExpiry(item.ExpiresAt)
+		if apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+
+		created[i] = &database.Task{
+			ID:          uuid.New(),
+			Title:       item.Title,
+			Description: item.Description,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			ExpiresAt:   expiresAt,
+			BlockedBy:   parseBlockers(item.BlockedBy),
+		}
+	}
+
+	// Blockers must already be stored: tasks in one batch cannot block each other
+	var blockerIDs []uuid.UUID
+	for _, task := range created {
+		if task != nil {
+			blockerIDs = append(blockerIDs, task.BlockedBy...)
+		}
+	}
+	blockers, err := h.findTasks(r.Context(), blockerIDs)
+	if err != nil {
+		h.logger.Error("Failed to retrieve blockers for batch create", "error", err)
+		h.storageFailed(w, err, "Failed to retrieve tasks")
+		return
+	}
+	for i, task := range created {
+		if task == nil {
+			continue
+		}
+		if apiErr := missingBlockerError(task.BlockedBy, blockers); apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			created[i] = nil
+		}
+	}
+
+	failed := slices.ContainsFunc(results, func(res batchItemResult) bool { return res.Error != nil })
+
+	switch {
+	case req.Atomic && failed:
+		for i := range results {
+			if results[i].Error == nil {
+				results[i].fail(http.StatusFailedDependency,
+					errors.NewFailedDependencyError("Not applied because another task in the batch failed").WithCode(errors.CodeBatchNotApplied))
+			}
+		}
+		h.logger.Warn("Atomic batch create rejected", "count", len(req.Tasks))
+
+	case req.Atomic:
+		if err := h.db.GetTaskRepository().CreateMany(r.Context(), created); err != nil {
+			h.logger.Error("Failed to create tasks in database", "error", err)
+			h.storageFailed(w, err, "Failed to create tasks")
+			return
+		}
+
+	default:
+		for i, task := range created {
+			if task == nil {
+				continue
+			}
+			if err := h.db.GetTaskRepository().Create(r.Context(), task); err != nil {
+				h.logger.Error("Failed to create task in database", "error", err, "task_id", task.ID)
+				results[i].fail(storageError(err, "Failed to create task"))
+			}
+		}
+	}
+
+	h.writeBatch(w, r, events.TaskCreated, http.StatusCreated, results, created)
+}
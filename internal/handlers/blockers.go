@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// maxBlockerSearch bounds how many tasks the cycle check visits. Chains
+// longer than that are not searched to the end.
+const maxBlockerSearch = 1000
+
+// parseBlockers converts blocker IDs whose format Validate() has checked.
+func parseBlockers(ids []string) []uuid.UUID {
+	if len(ids) == 0 {
+		return nil
+	}
+	blockers := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		blockers[i] = uuid.MustParse(id)
+	}
+	return blockers
+}
+
+// findTasks loads the stored tasks among ids, keyed by ID.
+func (h *TaskHandler) findTasks(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*database.Task, error) {
+	found := make(map[uuid.UUID]*database.Task, len(ids))
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	taskList, err := h.db.GetTaskRepository().FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range taskList {
+		found[task.ID] = task
+	}
+	return found, nil
+}
+
+// checkBlockers verifies that every blocker of task id exists and that none
+// of them is blocked by the task, directly or through its own blockers, which
+// would leave them all unable to complete. id is uuid.Nil for a task not
+// created yet, which nothing can be blocked by.
+func (h *TaskHandler) checkBlockers(ctx context.Context, id uuid.UUID, blockers []uuid.UUID) (*errors.APIError, error) {
+	if len(blockers) == 0 {
+		return nil, nil
+	}
+	if slices.Contains(blockers, id) {
+		return blockerCycleError(id), nil
+	}
+
+	found, err := h.findTasks(ctx, blockers)
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := missingBlockerError(blockers, found); apiErr != nil {
+		return apiErr, nil
+	}
+	if id == uuid.Nil {
+		return nil, nil
+	}
+
+	visited := make(map[uuid.UUID]bool, len(blockers))
+	for _, blocker := range blockers {
+		visited[blocker] = true
+	}
+	next := transitiveBlockers(found, visited)
+
+	for len(next) > 0 && len(visited) < maxBlockerSearch {
+		if slices.Contains(next, id) {
+			return blockerCycleError(id), nil
+		}
+		found, err = h.findTasks(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+		next = transitiveBlockers(found, visited)
+	}
+	return nil, nil
+}
+
+// transitiveBlockers collects the blockers of tasks not visited yet, marking
+// them visited.
+func transitiveBlockers(tasks map[uuid.UUID]*database.Task, visited map[uuid.UUID]bool) []uuid.UUID {
+	var next []uuid.UUID
+	for _, task := range tasks {
+		for _, blocker := range task.BlockedBy {
+			if !visited[blocker] {
+				visited[blocker] = true
+				next = append(next, blocker)
+			}
+		}
+	}
+	return next
+}
+
+// openBlockers returns the blockers found among stored that are not
+// completed. A blocker deleted since it was added no longer blocks.
+func openBlockers(blockers []uuid.UUID, stored map[uuid.UUID]*database.Task) []uuid.UUID {
+	var open []uuid.UUID
+	for _, blocker := range blockers {
+		if task := stored[blocker]; task != nil && !task.Completed {
+			open = append(open, blocker)
+		}
+	}
+	return open
+}
+
+// checkCompletable rejects completing a task while any of blockers is open.
+func (h *TaskHandler) checkCompletable(ctx context.Context, blockers []uuid.UUID) (*errors.APIError, error) {
+	stored, err := h.findTasks(ctx, blockers)
+	if err != nil {
+		return nil, err
+	}
+	return blockedError(openBlockers(blockers, stored)), nil
+}
+
+// missingBlockerError rejects the blockers not found among stored, or
+// returns nil when they all are.
+func missingBlockerError(blockers []uuid.UUID, stored map[uuid.UUID]*database.Task) *errors.APIError {
+	var details []errors.ValidationErrorDetail
+	for _, blocker := range blockers {
+		if stored[blocker] == nil {
+			details = append(details, errors.ValidationErrorDetail{
+				Field:   "BlockedBy",
+				Message: fmt.Sprintf("task %s does not exist", blocker),
+			})
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+	return errors.NewValidationError("Validation failed", details).WithCode(errors.CodeBlockerNotFound)
+}
+
+// blockerCycleError rejects blockers that would make task id block itself.
+func blockerCycleError(id uuid.UUID) *errors.APIError {
+	return errors.NewValidationError("Validation failed", []errors.ValidationErrorDetail{{
+		Field:   "BlockedBy",
+		Message: fmt.Sprintf("task %s would end up blocked by itself", id),
+	}}).WithCode(errors.CodeBlockerCycle)
+}
+
+// blockedError rejects completing a task with open blockers, or returns nil
+// when there are none.
+func blockedError(open []uuid.UUID) *errors.APIError {
+	if len(open) == 0 {
+		return nil
+	}
+	details := make([]errors.ValidationErrorDetail, len(open))
+	for i, blocker := range open {
+		details[i] = errors.ValidationErrorDetail{
+			Field:   "BlockedBy",
+			Message: fmt.Sprintf("task %s is not completed", blocker),
+		}
+	}
+	return errors.NewValidationError("Task is blocked by incomplete tasks", details).WithCode(errors.CodeTaskBlocked)
+}
+
+// Blockers handles GET /api/v1/tasks/{id}/blockers: the tasks the task is
+// blocked by, in the order they were given. Deleted blockers are left out.
+func (h *TaskHandler) Blockers(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid task ID format for blockers", "id", idStr)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
+	}
+
+	h.logger.Info("Fetching task blockers", "task_id", id)
+
+	task, err := h.db.GetTaskRepository().FindByID(r.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to retrieve task for blockers", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to retrieve task")
+		return
+	}
+	if task == nil {
+		h.logger.Info("Task not found for blockers", "task_id", id)
+		errors.RespondWithError(w, http.StatusNotFound,
+			errors.NewNotFoundError("Task not found").WithCode(errors.CodeTaskNotFound))
+		return
+	}
+
+	stored, err := h.findTasks(r.Context(), task.BlockedBy)
+	if err != nil {
+		h.logger.Error("Failed to retrieve blockers from database", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to retrieve tasks")
+		return
+	}
+
+	taskList := make([]*database.Task, 0, len(stored))
+	for _, blocker := range task.BlockedBy {
+		if blockerTask := stored[blocker]; blockerTask != nil {
+			taskList = append(taskList, blockerTask)
+		}
+	}
+
+	h.logger.Info("Task blockers retrieved successfully", "task_id", id, "count", len(taskList))
+
+	h.writeTasks(w, r, http.StatusOK, taskList, nil)
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+)
+
+// CacheStatsSource reports task cache counters; ok is false when caching is off.
+type CacheStatsSource interface {
+	CacheStats() (stats database.CacheStats, ok bool)
+}
+
+type cacheStatsResponse struct {
+	Enabled bool `json:"enabled"`
+	database.CacheStats
+}
+
+// CacheStats returns a handler reporting the task cache hit and miss counts.
+func CacheStats(source CacheStatsSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		stats, ok := source.CacheStats()
+		data, _ := json.Marshal(cacheStatsResponse{Enabled: ok, CacheStats: stats})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}
+}
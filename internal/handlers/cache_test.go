@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+)
+
+// staticCacheStats implements CacheStatsSource with fixed values
+type staticCacheStats struct {
+	stats database.CacheStats
+	ok    bool
+}
+
+func (s staticCacheStats) CacheStats() (database.CacheStats, bool) {
+	return s.stats, s.ok
+}
+
+// TestCacheStats tests reporting cache counters, enabled or not
+func TestCacheStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		source staticCacheStats
+		want   string
+	}{
+		{"disabled", staticCacheStats{}, `{"enabled":false,"hits":0,"misses":0,"entries":0}`},
+		{"enabled", staticCacheStats{database.CacheStats{Hits: 7, Misses: 3, Entries: 2}, true}, `{"enabled":true,"hits":7,"misses":3,"entries":2}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics/cache", nil)
+			w := httptest.NewRecorder()
+
+			CacheStats(tt.source)(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("expected status 200, got %d", w.Code)
+			}
+
+			if !json.Valid(w.Body.Bytes()) || w.Body.String() != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, w.Body.String())
+			}
+		})
+	}
+}
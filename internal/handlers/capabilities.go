@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"google.golang.org/protobuf/proto"
+)
+
+// ServerCapabilities describes what a running server supports, so clients can
+// adapt to its configuration instead of probing for it.
+type ServerCapabilities struct {
+	APIVersions          []int        `json:"apiVersions"`
+	Methods              []string     `json:"methods"`
+	Features             []string     `json:"features"`
+	Limits               ServerLimits `json:"limits"`
+	RequiredFields       []string     `json:"requiredFields"`
+	DiscardUnknownFields bool         `json:"discardUnknownFields"`
+	EmitUnsetFields      bool         `json:"emitUnsetFields"`
+	ContentTypes         ContentTypes `json:"contentTypes"`
+	RequestEncodings     []string     `json:"requestEncodings"`
+}
+
+// ServerLimits are the size limits requests are held to. MaxResults and
+// MaxResponseBytes are 0 when lists are not capped.
+type ServerLimits struct {
+	MaxTitleLength       int    `json:"maxTitleLength"`
+	MaxDescriptionLength int    `json:"maxDescriptionLength"`
+	MaxResults           int    `json:"maxResults"`
+	MaxResponseBytes     int    `json:"maxResponseBytes"`
+	MaxBatchSize         uint64 `json:"maxBatchSize"`
+}
+
+// ContentTypes lists the media types requests may carry and responses use.
+type ContentTypes struct {
+	Request  []string `json:"request"`
+	Response []string `json:"response"`
+}
+
+// Capabilities returns a handler reporting caps. The batch size limit comes
+// from the request schema, so it is filled in here rather than by the caller.
+func Capabilities(caps ServerCapabilities) http.HandlerFunc {
+	caps.Limits.MaxBatchSize = batchSizeLimit()
+
+	// Report empty lists as [] rather than null
+	for _, list := range []*[]string{&caps.Methods, &caps.Features, &caps.RequiredFields,
+		&caps.ContentTypes.Request, &caps.ContentTypes.Response, &caps.RequestEncodings} {
+		if *list == nil {
+			*list = []string{}
+		}
+	}
+	if caps.APIVersions == nil {
+		caps.APIVersions = []int{}
+	}
+
+	data, _ := json.Marshal(caps)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}
+}
+
+// batchSizeLimit reads the item limit of a batch create from its validation rules.
+func batchSizeLimit() uint64 {
+	field := (&tasks.BatchCreateTasksRequest{}).ProtoReflect().Descriptor().Fields().ByName("tasks")
+	rules, _ := proto.GetExtension(field.Options(), validate.E_Rules).(*validate.FieldRules)
+	return rules.GetRepeated().GetMaxItems()
+}
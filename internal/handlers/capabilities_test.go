@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// TestCapabilities tests that capabilities are reported with the batch limit filled in
+func TestCapabilities(t *testing.T) {
+	caps := ServerCapabilities{
+		APIVersions: []int{1},
+		Methods:     []string{"GET"},
+		Limits:      ServerLimits{MaxTitleLength: 50, MaxDescriptionLength: 200, MaxResults: 10},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/capabilities", nil)
+	w := httptest.NewRecorder()
+
+	Capabilities(caps)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %q", ct)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	limits, _ := got["limits"].(map[string]any)
+	want := map[string]float64{"maxTitleLength": 50, "maxDescriptionLength": 200, "maxResults": 10, "maxBatchSize": 100}
+	for name, value := range want {
+		if limits[name] != value {
+			t.Errorf("expected limits.%s %v, got %v", name, value, limits[name])
+		}
+	}
+
+	// Unset lists are empty rather than null
+	for _, name := range []string{"features", "requiredFields", "requestEncodings"} {
+		if list, ok := got[name].([]any); !ok || len(list) != 0 {
+			t.Errorf("expected %s to be [], got %v", name, got[name])
+		}
+	}
+	if methods, _ := got["methods"].([]any); !slices.Equal(methods, []any{"GET"}) {
+		t.Errorf("expected methods [GET], got %v", got["methods"])
+	}
+}
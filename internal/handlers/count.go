@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+// countByFields maps the field names CountBy accepts to the stored fields.
+var countByFields = map[string]string{
+	"completed": "completed",
+	"archived":  "archived",
+	"assignee":  "assigneeId",
+}
+
+// CountBy handles GET /api/v1/tasks/count-by?field=...: the number of tasks
+// matching the list filters, per value of the field.
+func (h *TaskHandler) CountBy(w http.ResponseWriter, r *http.Request) {
+	fieldName := r.URL.Query().Get("field")
+	field, ok := countByFields[fieldName]
+	if !ok {
+		h.logger.Warn("Unsupported count-by field", "field", fieldName)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("field must be completed, archived or assignee").WithCode(errors.CodeInvalidQuery))
+		return
+	}
+
+	query, apiErr := h.parseTaskQuery(r)
+	if apiErr != nil {
+		h.logger.Warn("Invalid count-by query", "error", apiErr.Message, "query", r.URL.RawQuery)
+		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
+		return
+	}
+
+	h.logger.Info("Counting tasks by field", "field", fieldName)
+
+	counts, err := h.db.GetTaskRepository().CountBy(r.Context(), field, query)
+	if err != nil {
+		h.logger.Error("Failed to count tasks in database", "error", err, "field", fieldName)
+		h.storageFailed(w, err, "Failed to count tasks")
+		return
+	}
+
+	data, err := json.Marshal(counts)
+	if err != nil {
+		h.encodingFailed(w, err)
+		return
+	}
+
+	h.write(w, http.StatusOK, data)
+}
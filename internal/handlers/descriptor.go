@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// protobufContentType is the media type of a binary protobuf message.
+const protobufContentType = "application/x-protobuf"
+
+// Descriptor returns a handler serving the compiled task protos as a binary
+// FileDescriptorSet, for tooling that generates bindings or decodes messages
+// without the .proto sources. The set holds every file the task protos
+// import, each after its dependencies, as protoc --include_imports writes it.
+func Descriptor() http.HandlerFunc {
+	data, _ := proto.Marshal(descriptorSet(tasks.File_api_proto_v1_tasks_proto))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", protobufContentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="tasks.protoset"`)
+		w.Write(data)
+	}
+}
+
+// descriptorSet collects file and everything it imports, dependencies first.
+func descriptorSet(file protoreflect.FileDescriptor) *descriptorpb.FileDescriptorSet {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+
+	var add func(protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+
+		imports := file.Imports()
+		for i := range imports.Len() {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	add(file)
+
+	return set
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// TestDescriptor tests that the descriptor set parses and describes the task messages
+func TestDescriptor(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/descriptor", nil)
+	w := httptest.NewRecorder()
+
+	Descriptor()(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != protobufContentType {
+		t.Errorf("expected Content-Type %s, got %s", protobufContentType, got)
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(w.Body.Bytes(), &set); err != nil {
+		t.Fatalf("failed to unmarshal descriptor set: %v", err)
+	}
+
+	// Building the files fails unless every import is in the set, in order
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		t.Fatalf("descriptor set does not resolve: %v", err)
+	}
+	for _, name := range []string{"tasks.Task", "tasks.CreateTaskRequest", "tasks.UpdateTaskRequest"} {
+		if _, err := files.FindDescriptorByName(protoreflect.FullName(name)); err != nil {
+			t.Errorf("expected %s in the descriptor set: %v", name, err)
+		}
+	}
+}
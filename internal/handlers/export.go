@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// exportStallTimeout is how long an export waits on a client that has stopped
+// reading before giving up.
+const exportStallTimeout = 30 * time.Second
+
+// exportFlushInterval is how many tasks are written between flushes, so
+// clients see progress without a syscall per line.
+const exportFlushInterval = 100
+
+// Export writes every task matching the list filters as newline-delimited
+// JSON, one task per line, straight from the repository cursor.
+func (h *TaskHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if format := r.URL.Query().Get("format"); format != "" && format != "ndjson" {
+		h.logger.Warn("Unsupported export format", "format", format)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid format: must be ndjson").WithCode(errors.CodeInvalidQuery))
+		return
+	}
+
+	query, apiErr := h.parseTaskQuery(r)
+	if apiErr != nil {
+		h.logger.Warn("Invalid export query", "error", apiErr.Message, "query", r.URL.RawQuery)
+		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
+		return
+	}
+
+	h.logger.Info("Exporting tasks")
+
+	// An export runs as long as the cursor does, so the server's write timeout
+	// would cut large ones off. Its own deadline moves on with every batch of
+	// lines instead, so only a client that stops reading runs into it.
+	controller := http.NewResponseController(w)
+	extendDeadline := func() {
+		if err := controller.SetWriteDeadline(time.Now().Add(exportStallTimeout)); err != nil && !stderrors.Is(err, http.ErrNotSupported) {
+			h.logger.Warn("Failed to extend write deadline for export", "error", err)
+		}
+	}
+	extendDeadline()
+
+	flusher, _ := w.(http.Flusher)
+	count := 0
+	var writeErr error
+
+	err := h.db.GetTaskRepository().Stream(r.Context(), query, func(task *database.Task) error {
+		line, err := h.marshalOptions(nil).Marshal(task.ToProto())
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			w.Header().Set("Content-Type", ndjsonContentType)
+		}
+
+		if _, writeErr = w.Write(append(line, '\n')); writeErr != nil {
+			return writeErr
+		}
+
+		count++
+		if count%exportFlushInterval == 0 {
+			extendDeadline()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		return nil
+	})
+
+	if writeErr != nil {
+		// The client is gone or stalled; nothing more can reach it
+		h.logger.Warn("Export aborted: failed to write response", "error", writeErr, "exported", count)
+		return
+	}
+
+	if err != nil {
+		h.logger.Error("Failed to export tasks", "error", err, "exported", count)
+		// Once a line is out the status is sent; the client sees a truncated stream
+		if count == 0 {
+			h.storageFailed(w, err, "Failed to export tasks")
+		}
+		return
+	}
+
+	if count == 0 {
+		w.Header().Set("Content-Type", ndjsonContentType)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	h.logger.Info("Successfully exported tasks", "count", count)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+type HealthHandler struct {
+	db     database.Database
+	logger *slog.Logger
+	// draining is set once shutdown begins; Ready fails from then on
+	draining atomic.Bool
+}
+
+func NewHealthHandler(db database.Database, logger *slog.Logger) *HealthHandler {
+	return &HealthHandler{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Health reports that the process is up; it never touches the database.
+func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	h.send(w, []byte(`{"status":"ok"}`))
+}
+
+// Drain makes Ready fail from now on, so load balancers stop routing to this
+// instance while it keeps serving the requests that still arrive.
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// Ready reports whether the service can handle traffic, checking both the
+// database connection and the task collection.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		errors.RespondWithError(w, http.StatusServiceUnavailable,
+			errors.NewUnavailableError("Server is shutting down").WithCode(errors.CodeShuttingDown))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	if err := h.db.Ping(ctx); err != nil {
+		h.logger.Error("Readiness check failed: database ping", "error", err)
+		errors.RespondWithError(w, http.StatusServiceUnavailable,
+			errors.NewUnavailableError("Database is unreachable").WithCode(errors.CodeDatabaseUnreachable))
+		return
+	}
+
+	if err := h.db.GetTaskRepository().HealthCheck(ctx); err != nil {
+		h.logger.Error("Readiness check failed: task repository", "error", err)
+		errors.RespondWithError(w, http.StatusServiceUnavailable,
+			errors.NewUnavailableError("Task storage is unavailable").WithCode(errors.CodeStorageUnavailable))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	h.send(w, []byte(`{"status":"ready"}`))
+}
+
+// send writes the body, logging rather than retrying if the prober is gone.
+func (h *HealthHandler) send(w http.ResponseWriter, data []byte) {
+	if _, err := w.Write(data); err != nil {
+		h.logger.Warn("Failed to write health response", "error", err)
+	}
+}
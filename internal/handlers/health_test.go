@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func setupHealthHandler() (*HealthHandler, *MockDatabase) {
+	mockDB := NewMockDatabase()
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	return NewHealthHandler(mockDB, logger), mockDB
+}
+
+// TestHealth tests the liveness endpoint
+func TestHealth(t *testing.T) {
+	h, _ := setupHealthHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	w := httptest.NewRecorder()
+
+	h.Health(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+// TestReady tests the readiness endpoint with a healthy repository
+func TestReady(t *testing.T) {
+	h, _ := setupHealthHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	w := httptest.NewRecorder()
+
+	h.Ready(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+// TestReadyRepositoryUnhealthy tests that a failing collection check fails readiness
+func TestReadyRepositoryUnhealthy(t *testing.T) {
+	h, mockDB := setupHealthHandler()
+	mockDB.taskRepo.healthErr = errors.New("not authorized on tasks")
+
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	w := httptest.NewRecorder()
+
+	h.Ready(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+}
+
+// TestReadyDraining tests that readiness fails once the handler is draining
+func TestReadyDraining(t *testing.T) {
+	h, _ := setupHealthHandler()
+	h.Drain()
+
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	w := httptest.NewRecorder()
+
+	h.Ready(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.Health(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected liveness to stay 200 while draining, got %d", w.Code)
+	}
+}
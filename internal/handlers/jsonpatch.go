@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
+	"github.com/PinceredCoder/restGo/internal/database"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const jsonPatchContentType = "application/json-patch+json"
+
+// jsonPatchWritableFields are the task members add/remove/replace may target.
+// Everything else in the document can only be checked with "test".
+var jsonPatchWritableFields = []string{"title", "description", "completed", "blockedBy"}
+
+var errJSONPatchTestFailed = stderrors.New("test operation failed")
+
+// jsonPatchOperation is one RFC 6902 operation. Only add, remove, replace and
+// test are supported; move and copy make no sense on a flat task.
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// taskPatchDocument renders a task as the JSON object the patch operates on,
+// using the same member names as API responses.
+func taskPatchDocument(task *database.Task) (map[string]any, error) {
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(task.ToProto())
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// applyJSONPatch applies ops to doc in order, stopping at the first failure.
+// A failed "test" returns errJSONPatchTestFailed; every other error means the
+// patch itself is invalid.
+func applyJSONPatch(doc map[string]any, ops []jsonPatchOperation) error {
+	for i, op := range ops {
+		member, err := jsonPatchMember(op.Path)
+		if err != nil {
+			return fmt.Errorf("operation %d: %w", i, err)
+		}
+
+		if op.Op != "test" && !slices.Contains(jsonPatchWritableFields, member) {
+			return fmt.Errorf("operation %d: path %s is read-only", i, op.Path)
+		}
+
+		current, exists := doc[member]
+
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return fmt.Errorf("operation %d: %s requires a value", i, op.Op)
+			}
+
+			var value any
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return fmt.Errorf("operation %d: invalid value: %w", i, err)
+			}
+
+			if op.Op != "add" && !exists {
+				return fmt.Errorf("operation %d: path %s does not exist", i, op.Path)
+			}
+
+			if op.Op == "test" {
+				if !reflect.DeepEqual(current, value) {
+					return fmt.Errorf("operation %d: %w: %s", i, errJSONPatchTestFailed, op.Path)
+				}
+				continue
+			}
+
+			doc[member] = value
+		case "remove":
+			if !exists {
+				return fmt.Errorf("operation %d: path %s does not exist", i, op.Path)
+			}
+			delete(doc, member)
+		case "move", "copy":
+			return fmt.Errorf("operation %d: %s is not supported", i, op.Op)
+		default:
+			return fmt.Errorf("operation %d: unknown op %q", i, op.Op)
+		}
+	}
+
+	return nil
+}
+
+// jsonPatchMember resolves a JSON Pointer to a top-level member name.
+func jsonPatchMember(path string) (string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return "", fmt.Errorf("path %q must start with /", path)
+	}
+
+	member := path[1:]
+	if strings.Contains(member, "/") {
+		return "", fmt.Errorf("path %q is not a task field", path)
+	}
+
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(member), nil
+}
+
+// jsonPatchUpdateRequest extracts the writable members of a patched document
+// as an update request, so the result goes through the normal validation.
+func jsonPatchUpdateRequest(doc map[string]any) (*tasks.UpdateTaskRequest, error) {
+	writable := make(map[string]any, len(jsonPatchWritableFields))
+	for _, field := range jsonPatchWritableFields {
+		if value, ok := doc[field]; ok {
+			writable[field] = value
+		}
+	}
+
+	data, err := json.Marshal(writable)
+	if err != nil {
+		return nil, err
+	}
+
+	var req tasks.UpdateTaskRequest
+	if err := protojson.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
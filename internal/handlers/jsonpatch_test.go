@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"testing"
+)
+
+func patchOps(t *testing.T, raw string) []jsonPatchOperation {
+	t.Helper()
+	var ops []jsonPatchOperation
+	if err := json.Unmarshal([]byte(raw), &ops); err != nil {
+		t.Fatalf("invalid test patch: %v", err)
+	}
+	return ops
+}
+
+// TestApplyJSONPatch tests each supported operation against a task document
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		want     map[string]any
+		wantErr  bool
+		testFail bool
+	}{
+		{
+			name:  "replace and add",
+			patch: `[{"op":"replace","path":"/title","value":"New"},{"op":"add","path":"/completed","value":true}]`,
+			want:  map[string]any{"id": "1", "title": "New", "description": "Old description", "completed": true},
+		},
+		{
+			name:  "remove",
+			patch: `[{"op":"remove","path":"/description"}]`,
+			want:  map[string]any{"id": "1", "title": "Old", "completed": false},
+		},
+		{
+			name:  "passing test on read-only field",
+			patch: `[{"op":"test","path":"/id","value":"1"},{"op":"replace","path":"/title","value":"New"}]`,
+			want:  map[string]any{"id": "1", "title": "New", "description": "Old description", "completed": false},
+		},
+		{name: "failing test", patch: `[{"op":"test","path":"/title","value":"Other"}]`, wantErr: true, testFail: true},
+		{name: "read-only field", patch: `[{"op":"replace","path":"/id","value":"2"}]`, wantErr: true},
+		{name: "replace missing member", patch: `[{"op":"replace","path":"/assigneeId","value":"bob"}]`, wantErr: true},
+		{name: "nested path", patch: `[{"op":"replace","path":"/title/0","value":"x"}]`, wantErr: true},
+		{name: "unsupported op", patch: `[{"op":"copy","from":"/title","path":"/description"}]`, wantErr: true},
+		{name: "missing value", patch: `[{"op":"replace","path":"/title"}]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := map[string]any{"id": "1", "title": "Old", "description": "Old description", "completed": false}
+
+			err := applyJSONPatch(doc, patchOps(t, tt.patch))
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if stderrors.Is(err, errJSONPatchTestFailed) != tt.testFail {
+					t.Errorf("unexpected error class: %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(doc) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, doc)
+			}
+			for k, v := range tt.want {
+				if doc[k] != v {
+					t.Errorf("expected %s=%v, got %v", k, v, doc[k])
+				}
+			}
+		})
+	}
+}
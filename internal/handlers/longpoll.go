@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+)
+
+// waitMargin is kept back from a request deadline, so a long poll that sees
+// no change still gets its empty answer out before the request times out.
+const waitMargin = time.Second
+
+// waitForTasks runs the list query. With a positive wait and nothing
+// matching, it re-runs the query after every change until something matches
+// or the wait is over, and then returns the empty result.
+func (h *TaskHandler) waitForTasks(ctx context.Context, query database.TaskQuery, wait time.Duration) ([]*database.Task, error) {
+	if wait <= 0 || h.notifier == nil {
+		return h.db.GetTaskRepository().FindAll(ctx, query)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		wait = min(wait, time.Until(deadline)-waitMargin)
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		// Taken before the query, so a change while it runs still wakes us
+		changed := h.notifier.Changed()
+
+		taskList, err := h.db.GetTaskRepository().FindAll(ctx, query)
+		if err != nil || len(taskList) > 0 {
+			return taskList, err
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return taskList, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -1,10 +1,18 @@
 package handlers
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"sync"
+	"testing"
+	"time"
 
 	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/events"
 	"github.com/google/uuid"
 )
 
@@ -37,16 +45,86 @@ func (m *MockDatabase) GetTaskRepository() database.TaskRepository {
 type MockTaskRepository struct {
 	mu    sync.RWMutex
 	tasks map[uuid.UUID]*database.Task
+	// delay makes every operation slow; it honors context cancellation like the driver does
+	delay time.Duration
+	// healthErr is returned from HealthCheck to simulate an unusable collection
+	healthErr error
+	// err is returned from every operation to simulate a failing database
+	err error
+	// extra is how many tasks past query.Limit FindAll returns, simulating a
+	// backend that pages loosely
+	extra int
+}
+
+func (r *MockTaskRepository) wait(ctx context.Context) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.delay == 0 {
+		return nil
+	}
+	select {
+	case <-time.After(r.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (r *MockTaskRepository) Create(ctx context.Context, task *database.Task) error {
+	if err := r.wait(ctx); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.tasks[task.ID] = task
 	return nil
 }
 
+func (r *MockTaskRepository) CreateMany(ctx context.Context, tasks []*database.Task) error {
+	if err := r.wait(ctx); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Like the transaction, a duplicate ID aborts the whole insert
+	for _, task := range tasks {
+		if _, exists := r.tasks[task.ID]; exists {
+			return fmt.Errorf("duplicate task ID %s", task.ID)
+		}
+	}
+	for _, task := range tasks {
+		r.tasks[task.ID] = task
+	}
+	return nil
+}
+
+func (r *MockTaskRepository) DeleteMany(ctx context.Context, query database.TaskQuery) (int64, error) {
+	if err := r.wait(ctx); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for id, task := range r.tasks {
+		if matchesQuery(task, query) {
+			delete(r.tasks, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
 func (r *MockTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*database.Task, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	task, exists := r.tasks[id]
@@ -56,31 +134,295 @@ func (r *MockTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*datab
 	return task, nil
 }
 
-func (r *MockTaskRepository) FindAll(ctx context.Context) ([]*database.Task, error) {
+func (r *MockTaskRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	if err := r.wait(ctx); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.tasks[id]
+	return exists, nil
+}
+
+func (r *MockTaskRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*database.Task, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tasks := make([]*database.Task, 0, len(ids))
+	for _, id := range ids {
+		if task, exists := r.tasks[id]; exists {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (r *MockTaskRepository) FindAll(ctx context.Context, query database.TaskQuery) ([]*database.Task, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	tasks := make([]*database.Task, 0, len(r.tasks))
 	for _, task := range r.tasks {
-		tasks = append(tasks, task)
+		if matchesQuery(task, query) {
+			tasks = append(tasks, task)
+		}
 	}
-	return tasks, nil
+	if query.Limit > 0 {
+		query.Limit += r.extra
+	}
+	return paginate(tasks, query), nil
+}
+
+func (r *MockTaskRepository) Stream(ctx context.Context, query database.TaskQuery, fn func(*database.Task) error) error {
+	tasks, err := r.FindAll(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (r *MockTaskRepository) Update(ctx context.Context, id uuid.UUID, task *database.Task) error {
+// matchesQuery mirrors the MongoDB filter built from a TaskQuery
+func matchesQuery(task *database.Task, query database.TaskQuery) bool {
+	if query.AssigneeID != nil {
+		if task.AssigneeID == nil || *task.AssigneeID != *query.AssigneeID {
+			return false
+		}
+	}
+	if query.Completed != nil && task.Completed != *query.Completed {
+		return false
+	}
+	if query.CreatedFrom != nil && task.CreatedAt < *query.CreatedFrom {
+		return false
+	}
+	if query.CreatedTo != nil && task.CreatedAt > *query.CreatedTo {
+		return false
+	}
+	if query.UpdatedSince != nil && task.UpdatedAt <= *query.UpdatedSince {
+		return false
+	}
+	if query.Archived != nil && task.Archived != *query.Archived {
+		return false
+	}
+	if query.HasDescription != nil && (task.Description != "") != *query.HasDescription {
+		return false
+	}
+	for _, condition := range query.Conditions {
+		if !matchesCondition(task, condition) {
+			return false
+		}
+	}
+	if query.After != nil && comparePosition(task, *query.After) <= 0 {
+		return false
+	}
+	if query.Before != nil && comparePosition(task, *query.Before) >= 0 {
+		return false
+	}
+	return true
+}
+
+func matchesCondition(task *database.Task, condition database.Condition) bool {
+	var c int
+	switch want := condition.Value.(type) {
+	case bool:
+		got := task.Completed
+		if condition.Field == "archived" {
+			got = task.Archived
+		}
+		if got != want {
+			c = 1
+		}
+	case string:
+		got := task.Title
+		if condition.Field == "assigneeId" {
+			got = ""
+			if task.AssigneeID != nil {
+				got = *task.AssigneeID
+			}
+		}
+		c = strings.Compare(got, want)
+	case int64:
+		var got int64
+		switch condition.Field {
+		case "createdAt":
+			got = task.CreatedAt
+		case "updatedAt":
+			got = task.UpdatedAt
+		case "completedAt":
+			// Like MongoDB, a missing field never matches a comparison
+			if task.CompletedAt == nil {
+				return condition.Op == "$ne"
+			}
+			got = *task.CompletedAt
+		}
+		c = cmp.Compare(got, want)
+	}
+
+	switch condition.Op {
+	case "$eq":
+		return c == 0
+	case "$ne":
+		return c != 0
+	case "$gt":
+		return c > 0
+	case "$gte":
+		return c >= 0
+	case "$lt":
+		return c < 0
+	default:
+		return c <= 0
+	}
+}
+
+func comparePosition(task *database.Task, pos database.Position) int {
+	if c := cmp.Compare(task.CreatedAt, pos.CreatedAt); c != 0 {
+		return c
+	}
+	return bytes.Compare(task.ID[:], pos.ID[:])
+}
+
+func (r *MockTaskRepository) CountBy(ctx context.Context, field string, query database.TaskQuery) (map[string]int64, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := map[string]int64{}
+	for _, task := range r.tasks {
+		if !matchesQuery(task, query) {
+			continue
+		}
+
+		var value any
+		switch field {
+		case "completed":
+			value = task.Completed
+		case "archived":
+			value = task.Archived
+		case "assigneeId":
+			value = database.GroupableFields[field]
+			if task.AssigneeID != nil {
+				value = *task.AssigneeID
+			}
+		default:
+			return nil, fmt.Errorf("cannot group tasks by %q", field)
+		}
+		counts[fmt.Sprint(value)]++
+	}
+	return counts, nil
+}
+
+func (r *MockTaskRepository) CountCompletedByDay(ctx context.Context, from, to int64, loc *time.Location) (map[string]int64, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := map[string]int64{}
+	for _, task := range r.tasks {
+		if !task.Completed || task.CompletedAt == nil || *task.CompletedAt < from || *task.CompletedAt >= to {
+			continue
+		}
+		counts[time.Unix(*task.CompletedAt, 0).In(loc).Format(time.DateOnly)]++
+	}
+	return counts, nil
+}
+
+// paginate sorts and slices tasks the way MongoDB applies sort, skip and limit.
+// Without an explicit sort the mock orders by createdAt, so tests never depend
+// on map iteration order; ties are broken by ID in the last key's direction.
+func paginate(tasks []*database.Task, query database.TaskQuery) []*database.Task {
+	sortKeys := query.Sort
+	if len(sortKeys) == 0 {
+		sortKeys = database.DefaultSort
+	}
+
+	slices.SortFunc(tasks, func(a, b *database.Task) int {
+		for _, key := range sortKeys {
+			c := compareField(a, b, key.Field)
+			if key.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		c := bytes.Compare(a.ID[:], b.ID[:])
+		if sortKeys[len(sortKeys)-1].Descending {
+			c = -c
+		}
+		return c
+	})
+
+	tasks = tasks[min(query.Offset, len(tasks)):]
+	if query.Limit > 0 && len(tasks) > query.Limit {
+		tasks = tasks[:query.Limit]
+	}
+	return tasks
+}
+
+func compareField(a, b *database.Task, field string) int {
+	switch field {
+	case "createdAt":
+		return cmp.Compare(a.CreatedAt, b.CreatedAt)
+	case "updatedAt":
+		return cmp.Compare(a.UpdatedAt, b.UpdatedAt)
+	case "title":
+		return strings.Compare(a.Title, b.Title)
+	default:
+		panic("mock: unsupported sort field " + field)
+	}
+}
+
+func (r *MockTaskRepository) FindOneAndUpdate(ctx context.Context, id uuid.UUID, update database.TaskUpdate) (*database.Task, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	_, exists := r.tasks[id]
-	if !exists {
-		return nil // Mimics MongoDB behavior
+	stored, exists := r.tasks[id]
+	if !exists || (update.UnmodifiedSince != nil && stored.UpdatedAt > *update.UnmodifiedSince) {
+		return nil, nil
 	}
 
-	r.tasks[id] = task
-	return nil
+	// Replace rather than modify, so tasks handed out earlier keep their values
+	task := *stored
+	update.Apply(&task)
+	r.tasks[id] = &task
+
+	before := *stored
+	return &before, nil
 }
 
 func (r *MockTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.wait(ctx); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -92,3 +434,156 @@ func (r *MockTaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	delete(r.tasks, id)
 	return nil
 }
+
+func (r *MockTaskRepository) UpdateMany(ctx context.Context, updates map[uuid.UUID]database.TaskUpdate) ([]uuid.UUID, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	// One lock for the whole batch stands in for the transaction
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var missed []uuid.UUID
+	for id, update := range updates {
+		stored, exists := r.tasks[id]
+		if !exists || (update.UnmodifiedSince != nil && stored.UpdatedAt > *update.UnmodifiedSince) {
+			missed = append(missed, id)
+		}
+	}
+	if len(missed) > 0 {
+		return missed, nil
+	}
+
+	for id, update := range updates {
+		task := *r.tasks[id]
+		update.Apply(&task)
+		r.tasks[id] = &task
+	}
+	return nil, nil
+}
+
+func (r *MockTaskRepository) UpsertMany(ctx context.Context, tasks []*database.Task) (*database.UpsertResult, error) {
+	if err := r.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := &database.UpsertResult{}
+	for _, task := range tasks {
+		stored, exists := r.tasks[task.ID]
+		switch {
+		case !exists:
+			r.tasks[task.ID] = task
+			result.Inserted = append(result.Inserted, task.ID)
+		case stored.UpdatedAt > task.UpdatedAt:
+			result.Conflicts = append(result.Conflicts, task.ID)
+		default:
+			// Like the upsert, createdAt and archived stay as stored
+			updated := *task
+			updated.CreatedAt = stored.CreatedAt
+			updated.Archived = stored.Archived
+			r.tasks[task.ID] = &updated
+			result.Updated = append(result.Updated, task.ID)
+		}
+	}
+	return result, nil
+}
+
+func (r *MockTaskRepository) HealthCheck(ctx context.Context) error {
+	return r.healthErr
+}
+
+// RecordingPublisher implements events.TaskEventPublisher, keeping every event for assertions
+type RecordingPublisher struct {
+	mu     sync.Mutex
+	events []events.TaskEvent
+}
+
+func (p *RecordingPublisher) Publish(ctx context.Context, event events.TaskEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *RecordingPublisher) Events() []events.TaskEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]events.TaskEvent(nil), p.events...)
+}
+
+// FakeClock implements Clock, returning a fixed time until advanced
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// TestMockFindAllPaging tests that the mock sorts and pages like MongoDB
+func TestMockFindAllPaging(t *testing.T) {
+	repo := NewMockDatabase().GetTaskRepository()
+
+	ids := []uuid.UUID{
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440001"),
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440002"),
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440003"),
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440004"),
+	}
+	// Two tasks share a createdAt, so their order comes from the ID tie-break
+	createdAt := []int64{300, 100, 200, 100}
+	titles := []string{"b", "d", "a", "c"}
+	for i, id := range ids {
+		repo.Create(context.Background(), &database.Task{ID: id, Title: titles[i], CreatedAt: createdAt[i]})
+	}
+
+	tests := []struct {
+		name  string
+		query database.TaskQuery
+		want  []uuid.UUID
+	}{
+		{"default order", database.TaskQuery{}, []uuid.UUID{ids[1], ids[3], ids[2], ids[0]}},
+		{"descending", database.TaskQuery{Sort: []database.SortKey{{Field: "createdAt", Descending: true}}}, []uuid.UUID{ids[0], ids[2], ids[3], ids[1]}},
+		{"by title", database.TaskQuery{Sort: []database.SortKey{{Field: "title"}}}, []uuid.UUID{ids[2], ids[0], ids[3], ids[1]}},
+		{"offset and limit", database.TaskQuery{Offset: 1, Limit: 2}, []uuid.UUID{ids[3], ids[2]}},
+		{"offset past the end", database.TaskQuery{Offset: 10}, []uuid.UUID{}},
+		{"after a position", database.TaskQuery{After: &database.Position{CreatedAt: 100, ID: ids[1]}}, []uuid.UUID{ids[3], ids[2], ids[0]}},
+		{"before a position", database.TaskQuery{Before: &database.Position{CreatedAt: 200, ID: ids[2]}}, []uuid.UUID{ids[1], ids[3]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repeat to catch any dependence on map iteration order
+			for range 5 {
+				found, err := repo.FindAll(context.Background(), tt.query)
+				if err != nil {
+					t.Fatalf("FindAll returned error: %v", err)
+				}
+
+				got := make([]uuid.UUID, len(found))
+				for i, task := range found {
+					got[i] = task.ID
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Fatalf("expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+// parseTaskQuery builds a repository query from the list endpoint's URL parameters.
+func (h *TaskHandler) parseTaskQuery(r *http.Request) (database.TaskQuery, *errors.APIError) {
+	var query database.TaskQuery
+	params := r.URL.Query()
+
+	if params.Has("assignee") {
+		assignee := params.Get("assignee")
+
+		// Reuse the proto rule so the filter accepts exactly what assign accepts
+		if err := (&tasks.AssignTaskRequest{AssigneeId: assignee}).Validate(); err != nil {
+			return query, errors.NewBadRequestError("Invalid assignee identifier").WithCode(errors.CodeInvalidQuery)
+		}
+
+		query.AssigneeID = &assignee
+	}
+
+	query.Completed = h.defaultCompleted
+	if params.Has("completed") {
+		switch value := params.Get("completed"); value {
+		case "all":
+			query.Completed = nil
+		default:
+			completed, err := strconv.ParseBool(value)
+			if err != nil {
+				return query, errors.NewBadRequestError("completed must be true, false or all").WithCode(errors.CodeInvalidQuery)
+			}
+			query.Completed = &completed
+		}
+	}
+
+	// Archived tasks stay out of the list unless asked for
+	archived := false
+	query.Archived = &archived
+	if params.Has("archived") {
+		switch value := params.Get("archived"); value {
+		case "all":
+			query.Archived = nil
+		default:
+			archived, err := strconv.ParseBool(value)
+			if err != nil {
+				return query, errors.NewBadRequestError("archived must be true, false or all").WithCode(errors.CodeInvalidQuery)
+			}
+			query.Archived = &archived
+		}
+	}
+
+	if params.Has("has_description") {
+		hasDescription, err := strconv.ParseBool(params.Get("has_description"))
+		if err != nil {
+			return query, errors.NewBadRequestError("has_description must be true or false").WithCode(errors.CodeInvalidQuery)
+		}
+		query.HasDescription = &hasDescription
+	}
+
+	if params.Has("filter") {
+		conditions, err := database.ParseFilter(params.Get("filter"))
+		if err != nil {
+			return query, errors.NewBadRequestError("Invalid filter: " + err.Error()).WithCode(errors.CodeInvalidQuery)
+		}
+		query.Conditions = conditions
+	}
+
+	query.Sort = h.defaultSort
+	if params.Has("sort") {
+		sort, err := database.ParseSort(params.Get("sort"))
+		if err != nil {
+			return query, errors.NewBadRequestError("Invalid sort: " + err.Error()).WithCode(errors.CodeInvalidQuery)
+		}
+		query.Sort = sort
+	}
+
+	bounds := []struct {
+		name   string
+		target **int64
+	}{
+		{"created_from", &query.CreatedFrom},
+		{"created_to", &query.CreatedTo},
+		{"updated_since", &query.UpdatedSince},
+	}
+
+	for _, bound := range bounds {
+		if !params.Has(bound.name) {
+			continue
+		}
+
+		value, err := parseTimestamp(params.Get(bound.name))
+		if err != nil {
+			return query, errors.NewBadRequestError(bound.name + " must be a unix timestamp or an RFC 3339 time").WithCode(errors.CodeInvalidQuery)
+		}
+
+		*bound.target = &value
+	}
+
+	if query.CreatedFrom != nil && query.CreatedTo != nil && *query.CreatedFrom > *query.CreatedTo {
+		return query, errors.NewBadRequestError("created_from must not be after created_to").WithCode(errors.CodeInvalidQuery)
+	}
+
+	return query, nil
+}
+
+// parseTimestamp accepts unix seconds or an RFC 3339 time such as
+// 2025-11-13T10:00:00Z, the format timestamps are returned in.
+func parseTimestamp(value string) (int64, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return seconds, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
+
+// maxWait bounds ?wait= on the list endpoint.
+const maxWait = time.Minute
+
+// taskFields maps the task's JSON field names to the stored fields.
+var taskFields = map[string]string{
+	"id":          "_id",
+	"title":       "title",
+	"description": "description",
+	"completed":   "completed",
+	"assigneeId":  "assigneeId",
+	"archived":    "archived",
+	"completedAt": "completedAt",
+	"createdAt":   "createdAt",
+	"updatedAt":   "updatedAt",
+	"blockedBy":   "blockedBy",
+	"expiresAt":   "expiresAt",
+}
+
+// parseFields reads ?fields=, a comma-separated list of task JSON field
+// names, returning them along with the stored fields to load. Without the
+// parameter both are nil and tasks are returned whole.
+func parseFields(params url.Values) (fields, stored []string, apiErr *errors.APIError) {
+	if !params.Has("fields") {
+		return nil, nil, nil
+	}
+
+	for field := range strings.SplitSeq(params.Get("fields"), ",") {
+		field = strings.TrimSpace(field)
+		storedField, ok := taskFields[field]
+		if !ok {
+			return nil, nil, errors.NewBadRequestError("Unknown field " + strconv.Quote(field) + " in fields").WithCode(errors.CodeInvalidQuery)
+		}
+		fields = append(fields, field)
+		stored = append(stored, storedField)
+	}
+	return fields, stored, nil
+}
+
+// parseWait reads ?wait=, a duration like 30s. Zero, the default, answers at once.
+func parseWait(params url.Values) (time.Duration, *errors.APIError) {
+	if !params.Has("wait") {
+		return 0, nil
+	}
+
+	wait, err := time.ParseDuration(params.Get("wait"))
+	if err != nil || wait < 0 || wait > maxWait {
+		return 0, errors.NewBadRequestError("wait must be a duration like 30s, at most 1m").WithCode(errors.CodeInvalidQuery)
+	}
+	return wait, nil
+}
+
+// parsePaging reads ?limit= and ?offset=. It returns a zero limit when the
+// server's cap should size the page: when limit is missing or 0, and when it
+// asks for more than maxResults (a zero maxResults means no cap).
+func parsePaging(params url.Values, maxResults int) (limit, offset int, apiErr *errors.APIError) {
+	values := []struct {
+		name   string
+		target *int
+	}{
+		{"limit", &limit},
+		{"offset", &offset},
+	}
+
+	for _, value := range values {
+		if !params.Has(value.name) {
+			continue
+		}
+
+		n, err := strconv.Atoi(params.Get(value.name))
+		if err != nil || n < 0 {
+			return 0, 0, errors.NewBadRequestError(value.name + " must be a non-negative integer").WithCode(errors.CodeInvalidQuery)
+		}
+		*value.target = n
+	}
+
+	if maxResults > 0 && limit > maxResults {
+		limit = 0
+	}
+	return limit, offset, nil
+}
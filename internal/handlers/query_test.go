@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/url"
+	"testing"
+)
+
+// TestParsePaging tests limit and offset parsing, including the cap and invalid values
+func TestParsePaging(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		maxResults int
+		wantLimit  int
+		wantOffset int
+		wantErr    bool
+	}{
+		{"no paging", "", 100, 0, 0, false},
+		{"limit and offset", "limit=10&offset=20", 100, 10, 20, false},
+		{"zero limit uses the cap", "limit=0", 100, 0, 0, false},
+		{"limit at the cap", "limit=100", 100, 100, 0, false},
+		{"limit above the cap uses the cap", "limit=1000000", 100, 0, 0, false},
+		{"no cap keeps a large limit", "limit=1000000", 0, 1000000, 0, false},
+		{"negative limit", "limit=-1", 100, 0, 0, true},
+		{"negative offset", "offset=-5", 100, 0, 0, true},
+		{"non-numeric limit", "limit=ten", 100, 0, 0, true},
+		{"limit beyond int", "limit=99999999999999999999", 100, 0, 0, true},
+		{"empty offset", "offset=", 100, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, _ := url.ParseQuery(tt.query)
+
+			limit, offset, apiErr := parsePaging(params, tt.maxResults)
+
+			if tt.wantErr {
+				if apiErr == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if apiErr != nil {
+				t.Fatalf("unexpected error: %s", apiErr.Message)
+			}
+			if limit != tt.wantLimit || offset != tt.wantOffset {
+				t.Errorf("expected limit %d offset %d, got %d and %d", tt.wantLimit, tt.wantOffset, limit, offset)
+			}
+		})
+	}
+}
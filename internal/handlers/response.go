@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+	"slices"
+	"strconv"
+
+	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
+	"github.com/PinceredCoder/restGo/internal/helpers"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// wantsEnvelope reports whether the response should keep the {"task": ...}
+// or {"tasks": [...]} wrapper. Only ?envelope=false (or another false value
+// strconv.ParseBool accepts) drops it, so an unrecognized value can never
+// turn a successful write into an error.
+func wantsEnvelope(r *http.Request) bool {
+	envelope, err := strconv.ParseBool(r.URL.Query().Get("envelope"))
+	return err != nil || envelope
+}
+
+// writeTask writes a single task, as GetTaskResponse or as the bare task.
+// Non-empty fields trims it to those fields (see parseFields).
+func (h *TaskHandler) writeTask(w http.ResponseWriter, r *http.Request, status int, task *database.Task, fields []string) {
+	protoTask := selectFields(task.ToProto(), fields)
+	if !wantsEnvelope(r) {
+		h.writeMessage(w, status, protoTask, h.marshalOptions(fields))
+		return
+	}
+	h.writeMessage(w, status, &tasks.GetTaskResponse{Task: protoTask}, h.marshalOptions(fields))
+}
+
+// writeTasks writes a task list, as ListTasksResponse or as a bare array.
+// Non-empty fields trims every task to those fields.
+func (h *TaskHandler) writeTasks(w http.ResponseWriter, r *http.Request, status int, taskList []*database.Task, fields []string) {
+	protoTasks := helpers.Map(taskList, func(t *database.Task) *tasks.Task { return selectFields(t.ToProto(), fields) })
+
+	if wantsEnvelope(r) {
+		h.writeMessage(w, status, &tasks.ListTasksResponse{Tasks: protoTasks}, h.marshalOptions(fields))
+		return
+	}
+
+	// protojson only marshals messages, so the array is joined by hand
+	opts := h.marshalOptions(fields)
+	data := []byte{'['}
+	for i, task := range protoTasks {
+		taskData, err := opts.Marshal(task)
+		if err != nil {
+			h.encodingFailed(w, err)
+			return
+		}
+		if i > 0 {
+			data = append(data, ',')
+		}
+		data = append(data, taskData...)
+	}
+	data = append(data, ']')
+
+	h.write(w, status, data)
+}
+
+// fitResponseBytes counts how many leading tasks of taskList fit in
+// maxResponseBytes once encoded as a JSON array, and always at least one so
+// paging makes progress.
+func (h *TaskHandler) fitResponseBytes(taskList []*database.Task, fields []string) (int, error) {
+	opts := h.marshalOptions(fields)
+	size := len("[]")
+	for i, task := range taskList {
+		taskData, err := opts.Marshal(selectFields(task.ToProto(), fields))
+		if err != nil {
+			return 0, err
+		}
+		if i > 0 {
+			size++ // the separating comma
+		}
+		size += len(taskData)
+		if size > h.maxResponseBytes && i > 0 {
+			return i, nil
+		}
+	}
+	return len(taskList), nil
+}
+
+// marshalOptions encodes tasks for a response, listing unset fields when the
+// handler was built WithEmitUnset, except in tasks trimmed to fields.
+func (h *TaskHandler) marshalOptions(fields []string) protojson.MarshalOptions {
+	return protojson.MarshalOptions{EmitUnpopulated: h.emitUnset && len(fields) == 0}
+}
+
+func (h *TaskHandler) writeMessage(w http.ResponseWriter, status int, msg proto.Message, opts protojson.MarshalOptions) {
+	data, err := opts.Marshal(msg)
+	if err != nil {
+		h.encodingFailed(w, err)
+		return
+	}
+	h.write(w, status, data)
+}
+
+func (h *TaskHandler) write(w http.ResponseWriter, status int, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	h.send(w, data)
+}
+
+// send writes an already framed body. A failed write means the client is gone
+// or stopped reading until WRITE_TIMEOUT hit; the status line may already be
+// out, so the error is only logged and nothing more is written.
+func (h *TaskHandler) send(w http.ResponseWriter, data []byte) {
+	if n, err := w.Write(data); err != nil {
+		h.logger.Warn("Failed to write response", "error", err, "written", n, "size", len(data))
+	}
+}
+
+func (h *TaskHandler) encodingFailed(w http.ResponseWriter, err error) {
+	h.logger.Error("Failed to marshal response", "error", err)
+	errors.RespondWithError(w, http.StatusInternalServerError,
+		errors.NewInternalError("Failed to encode response").WithCode(errors.CodeEncodingFailure))
+}
+
+// storageError picks the response for a failed repository call: 503 when the
+// database was too busy to take the operation before its deadline, which is
+// worth retrying, and 500 with message otherwise.
+func storageError(err error, message string) (int, *errors.APIError) {
+	if stderrors.Is(err, database.ErrUnavailable) {
+		return http.StatusServiceUnavailable,
+			errors.NewUnavailableError("Task storage is busy, retry later").WithCode(errors.CodeStorageBusy)
+	}
+	return http.StatusInternalServerError, errors.NewInternalError(message).WithCode(errors.CodeStorageFailure)
+}
+
+func (h *TaskHandler) storageFailed(w http.ResponseWriter, err error, message string) {
+	status, apiErr := storageError(err, message)
+	if status == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "1")
+	}
+	errors.RespondWithError(w, status, apiErr)
+}
+
+// selectFields clears every field of task not named in fields, by JSON name.
+// The ID is always kept.
+func selectFields(task *tasks.Task, fields []string) *tasks.Task {
+	if len(fields) == 0 {
+		return task
+	}
+	m := task.ProtoReflect()
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.JSONName() != "id" && !slices.Contains(fields, fd.JSONName()) {
+			m.Clear(fd)
+		}
+		return true
+	})
+	return task
+}
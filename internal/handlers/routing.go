@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+// NotFound responds with a JSON 404 for paths no route matches.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	errors.RespondWithError(w, http.StatusNotFound,
+		errors.NewNotFoundError("No route matches "+r.URL.Path).WithCode(errors.CodeRouteNotFound))
+}
+
+// MethodNotAllowed responds with a JSON 405 for methods a route does not serve.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	errors.RespondWithError(w, http.StatusMethodNotAllowed,
+		errors.NewMethodNotAllowedError("Method "+r.Method+" is not allowed on "+r.URL.Path).WithCode(errors.CodeMethodNotAllowed))
+}
+
+// Head answers HEAD requests with the status and headers get sends, including
+// the Content-Length of the body it would have written, but no body.
+func Head(get http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		hw := &headWriter{ResponseWriter: w}
+		get(hw, r)
+		hw.flush()
+	}
+}
+
+// headWriter discards the body and holds the status back until the body
+// length is known, since Content-Length must be set before the header is sent.
+type headWriter struct {
+	http.ResponseWriter
+	status int
+	length int
+}
+
+func (w *headWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *headWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	w.length += len(b)
+	return len(b), nil
+}
+
+func (w *headWriter) flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.Header().Get("Content-Length") == "" {
+		w.Header().Set("Content-Length", strconv.Itoa(w.length))
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
+	"github.com/PinceredCoder/restGo/internal/errors"
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema returns a JSON Schema for the create and update request bodies. It is
+// derived from the proto definitions and their validation rules, plus the
+// configured title and description limits and required fields, so it always
+// matches what the handlers accept.
+func (h *TaskHandler) Schema(w http.ResponseWriter, r *http.Request) {
+	// Limits enforced by the handlers rather than by proto rules
+	maxLengths := map[string]int{
+		"title":       h.maxTitleLen,
+		"description": h.maxDescriptionLen,
+	}
+
+	schema := map[string]any{
+		"$schema": jsonSchemaDialect,
+		"$defs": map[string]any{
+			"CreateTaskRequest": messageSchema((&tasks.CreateTaskRequest{}).ProtoReflect().Descriptor(), maxLengths, h.requiredFields, h.discardUnknown),
+			"UpdateTaskRequest": messageSchema((&tasks.UpdateTaskRequest{}).ProtoReflect().Descriptor(), maxLengths, h.requiredFields, h.discardUnknown),
+		},
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		h.logger.Error("Failed to marshal schema", "error", err)
+		errors.RespondWithError(w, http.StatusInternalServerError,
+			errors.NewInternalError("Failed to encode response").WithCode(errors.CodeEncodingFailure))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/schema+json")
+	h.send(w, data)
+}
+
+// messageSchema describes a request message as a JSON object, using the JSON
+// member names protojson accepts. Unknown members are only allowed when the
+// handler discards them. Fields in requiredFields must hold more than
+// whitespace.
+func messageSchema(desc protoreflect.MessageDescriptor, maxLengths map[string]int, requiredFields []string, discardUnknown bool) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+
+	fields := desc.Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		rules, _ := proto.GetExtension(field.Options(), validate.E_Rules).(*validate.FieldRules)
+
+		var property map[string]any
+		if field.IsList() {
+			property = map[string]any{
+				"type":  "array",
+				"items": fieldSchema(field, rules.GetRepeated().GetItems()),
+			}
+			repeatedRules(property, rules.GetRepeated())
+		} else {
+			property = fieldSchema(field, rules)
+		}
+
+		if limit, ok := maxLengths[field.JSONName()]; ok {
+			property["maxLength"] = limit
+		}
+
+		configured := slices.Contains(requiredFields, field.JSONName())
+		if configured {
+			property["minLength"] = max(rules.GetString_().GetMinLen(), 1)
+			property["pattern"] = `\S`
+		}
+
+		properties[field.JSONName()] = property
+
+		if rules.GetString_().GetMinLen() > 0 || configured {
+			required = append(required, field.JSONName())
+		}
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": discardUnknown,
+	}
+}
+
+// fieldSchema describes a single (non-repeated) value of field.
+func fieldSchema(field protoreflect.FieldDescriptor, rules *validate.FieldRules) map[string]any {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range values.Len() {
+			names[i] = string(values.Get(i).Name())
+		}
+		return map[string]any{"type": "string", "enum": names}
+	case protoreflect.StringKind:
+		schema := map[string]any{"type": "string"}
+		stringRules(schema, rules.GetString_())
+		return schema
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Uint32Kind, protoreflect.Uint64Kind:
+		return map[string]any{"type": "integer"}
+	case protoreflect.MessageKind:
+		// protojson writes timestamps as RFC 3339 strings
+		if field.Message().FullName() == "google.protobuf.Timestamp" {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+		return map[string]any{}
+	default:
+		return map[string]any{}
+	}
+}
+
+func stringRules(schema map[string]any, rules *validate.StringRules) {
+	if rules == nil {
+		return
+	}
+	if rules.MinLen != nil {
+		schema["minLength"] = rules.GetMinLen()
+	}
+	if rules.MaxLen != nil {
+		schema["maxLength"] = rules.GetMaxLen()
+	}
+	if rules.Pattern != nil {
+		schema["pattern"] = rules.GetPattern()
+	}
+	if rules.GetUuid() {
+		schema["format"] = "uuid"
+	}
+}
+
+func repeatedRules(schema map[string]any, rules *validate.RepeatedRules) {
+	if rules == nil {
+		return
+	}
+	if rules.MinItems != nil {
+		schema["minItems"] = rules.GetMinItems()
+	}
+	if rules.MaxItems != nil {
+		schema["maxItems"] = rules.GetMaxItems()
+	}
+	if rules.GetUnique() {
+		schema["uniqueItems"] = true
+	}
+}
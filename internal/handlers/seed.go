@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"io"
+	"net/http"
+	"strings"
+
+	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
+	"github.com/PinceredCoder/restGo/internal/events"
+	"github.com/google/uuid"
+)
+
+// sampleTasks are seeded when the request names no tasks of its own.
+// Every one has a description, so they pass any REQUIRED_FIELDS.
+var sampleTasks = []*tasks.SeedTask{
+	{Title: "Set up the development environment", Description: "Install Go, Docker and the protobuf tools"},
+	{Title: "Write the API documentation", Description: "Cover every endpoint with a curl example"},
+	{Title: "Review open pull requests", Description: "Start with the oldest"},
+	{Title: "Plan the next release", Description: "Collect the changes since the last tag"},
+	{Title: "Rotate the staging credentials", Description: "Update the secrets in CI afterwards"},
+}
+
+// WithSeedToken enables POST /api/v1/admin/seed for requests carrying token
+// as a bearer token. Without it, the default, the endpoint answers 403.
+func WithSeedToken(token string) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.seedToken = token
+	}
+}
+
+// Seed handles POST /api/v1/admin/seed: it deletes every task and inserts the
+// tasks in the request, or the sample tasks when there are none. Meant for
+// demos and end-to-end tests; the deletion and the inserts are not one
+// transaction.
+func (h *TaskHandler) Seed(w http.ResponseWriter, r *http.Request) {
+	if h.seedToken == "" {
+		h.logger.Warn("Rejected seed request: seeding is disabled")
+		errors.RespondWithError(w, http.StatusForbidden,
+			errors.NewForbiddenError("Seeding is disabled on this server").WithCode(errors.CodeSeedDisabled))
+		return
+	}
+
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(token), []byte(h.seedToken)) != 1 {
+		h.logger.Warn("Rejected seed request: invalid admin token")
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		errors.RespondWithError(w, http.StatusUnauthorized,
+			errors.NewUnauthorizedError("A valid admin bearer token is required").WithCode(errors.CodeAdminTokenInvalid))
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Warn("Failed to read seed request body", "error", err)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Failed to read request body").WithCode(errors.CodeUnreadableBody))
+		return
+	}
+
+	var req tasks.SeedTasksRequest
+	if !isEmptyBody(data) {
+		if err := h.unmarshal(data, &req); err != nil {
+			h.logger.Warn("Invalid JSON format in seed request", "error", err)
+			errors.RespondWithError(w, http.StatusBadRequest, h.convertUnmarshalError(err))
+			return
+		}
+
+		if err := req.Validate(); err != nil {
+			h.logger.Warn("Validation failed for seed request", "error", err)
+			apiErr := h.convertValidationError(err)
+			errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+			return
+		}
+	}
+
+	seed := req.Tasks
+	if len(seed) == 0 {
+		seed = sampleTasks
+	}
+
+	now := h.clock.Now().Unix()
+	taskList := make([]*database.Task, len(seed))
+	for i, item := range seed {
+		if apiErr := h.validateRequired(item.Title, item.Description); apiErr != nil {
+			errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+			return
+		}
+		if apiErr := h.validateLengths(item.Title, item.Description); apiErr != nil {
+			errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+			return
+		}
+		if apiErr := h.validateCharacters(item.Title, item.Description); apiErr != nil {
+			errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+			return
+		}
+		expiresAt, apiErr := h.validateExpiry(item.ExpiresAt)
+		if apiErr != nil {
+			errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+			return
+		}
+
+		taskList[i] = &database.Task{
+			ID:          uuid.New(),
+			Title:       item.Title,
+			Description: item.Description,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			ExpiresAt:   expiresAt,
+		}
+	}
+
+	deleted, err := h.db.GetTaskRepository().DeleteMany(r.Context(), database.TaskQuery{})
+	if err != nil {
+		h.logger.Error("Failed to clear tasks for seeding", "error", err)
+		h.storageFailed(w, err, "Failed to clear tasks")
+		return
+	}
+
+	if err := h.db.GetTaskRepository().CreateMany(r.Context(), taskList); err != nil {
+		h.logger.Error("Failed to insert seed tasks", "error", err, "deleted", deleted)
+		h.storageFailed(w, err, "Failed to create tasks")
+		return
+	}
+
+	for _, task := range taskList {
+		h.publish(r.Context(), events.TaskCreated, task.ID, task)
+	}
+
+	h.logger.Info("Seeded tasks", "deleted", deleted, "created", len(taskList))
+
+	h.writeTasks(w, r, http.StatusCreated, taskList, nil)
+}
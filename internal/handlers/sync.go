@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
+	"github.com/PinceredCoder/restGo/internal/events"
+	"github.com/google/uuid"
+)
+
+// Sync handles POST /api/v1/sync: a client pushing the tasks it changed while
+// offline, with their IDs and timestamps. Valid tasks are upserted in one
+// round trip. A task whose stored copy was updated after the pushed
+// updated_at is left alone and answered with 409 and the stored copy, so the
+// client can merge and push again. Blockers are held to the same rules as on
+// update, so they must already be stored. Results are reported per task as in
+// the batch endpoints.
+func (h *TaskHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Warn("Failed to read sync request body", "error", err)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Failed to read request body").WithCode(errors.CodeUnreadableBody))
+		return
+	}
+
+	if isEmptyBody(data) {
+		h.logger.Warn("Empty request body for sync")
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Request body is required").WithCode(errors.CodeBodyRequired))
+		return
+	}
+
+	var req tasks.SyncTasksRequest
+	if err := h.unmarshal(data, &req); err != nil {
+		h.logger.Warn("Invalid JSON format in sync request", "error", err)
+		errors.RespondWithError(w, http.StatusBadRequest, h.convertUnmarshalError(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.logger.Warn("Validation failed for sync request", "error", err)
+		apiErr := h.convertValidationError(err)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	h.logger.Info("Syncing tasks", "count", len(req.Tasks))
+
+	now := h.clock.Now().Unix()
+	results := make([]batchItemResult, len(req.Tasks))
+	pending := make([]*database.Task, 0, len(req.Tasks))
+	index := make(map[uuid.UUID]int, len(req.Tasks))
+
+	for i, item := range req.Tasks {
+		results[i] = batchItemResult{Index: i, ID: item.Id}
+
+		if err := item.Validate(); err != nil {
+			apiErr := h.convertValidationError(err)
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+		if apiErr := h.validateRequired(item.Title, item.Description); apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+		if apiErr := h.validateLengths(item.Title, item.Description); apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+		if apiErr := h.validateCharacters(item.Title, item.Description); apiErr != nil {
+			results[i].fail(rejectionStatus(apiErr), apiErr)
+			continue
+		}
+
+		task := syncedTask(item)
+		// A version from the future would win every later conflict
+		if task.UpdatedAt > now {
+			results[i].fail(http.StatusUnprocessableEntity, errors.NewValidationError("Validation failed", []errors.ValidationErrorDetail{{
+				Field:   "UpdatedAt",
+				Message: "value may not be in the future",
+			}}).WithCode(errors.CodeSyncVersionInvalid))
+			continue
+		}
+		if _, seen := index[task.ID]; seen {
+			results[i].fail(http.StatusUnprocessableEntity, duplicateIDError())
+			continue
+		}
+
+		index[task.ID] = i
+		pending = append(pending, task)
+	}
+
+	rejected, err := h.checkSyncedBlockers(r.Context(), pending)
+	if err != nil {
+		h.logger.Error("Failed to check blockers of synced tasks", "error", err)
+		h.storageFailed(w, err, "Failed to sync tasks")
+		return
+	}
+	pending = slices.DeleteFunc(pending, func(task *database.Task) bool {
+		apiErr := rejected[task.ID]
+		if apiErr != nil {
+			results[index[task.ID]].fail(rejectionStatus(apiErr), apiErr)
+		}
+		return apiErr != nil
+	})
+
+	outcome, err := h.db.GetTaskRepository().UpsertMany(r.Context(), pending)
+	if err != nil {
+		h.logger.Error("Failed to upsert synced tasks", "error", err)
+		h.storageFailed(w, err, "Failed to sync tasks")
+		return
+	}
+
+	// Report every task as stored, which for conflicts is the server's copy
+	// and for updates keeps the stored createdAt and archived flag
+	ids := make([]uuid.UUID, len(pending))
+	for i, task := range pending {
+		ids[i] = task.ID
+	}
+	found, err := h.db.GetTaskRepository().FindByIDs(r.Context(), ids)
+	if err != nil {
+		h.logger.Error("Failed to retrieve synced tasks", "error", err)
+		h.storageFailed(w, err, "Failed to retrieve tasks")
+		return
+	}
+	// A task deleted since the upsert is reported as pushed
+	stored := make(map[uuid.UUID]*database.Task, len(pending))
+	for _, task := range pending {
+		stored[task.ID] = task
+	}
+	for _, task := range found {
+		stored[task.ID] = task
+	}
+
+	report := func(ids []uuid.UUID, status int) bool {
+		for _, id := range ids {
+			taskData, err := h.marshalOptions(nil).Marshal(stored[id].ToProto())
+			if err != nil {
+				h.encodingFailed(w, err)
+				return false
+			}
+			results[index[id]].Status = status
+			results[index[id]].Task = taskData
+		}
+		return true
+	}
+	if !report(outcome.Inserted, http.StatusCreated) ||
+		!report(outcome.Updated, http.StatusOK) ||
+		!report(outcome.Conflicts, http.StatusConflict) {
+		return
+	}
+
+	for _, id := range outcome.Conflicts {
+		results[index[id]].Error = errors.NewConflictError("Task was updated on the server after this copy").
+			WithCode(errors.CodeSyncConflict)
+	}
+	for _, id := range outcome.Inserted {
+		h.publish(r.Context(), events.TaskCreated, id, stored[id])
+	}
+	for _, id := range outcome.Updated {
+		h.publish(r.Context(), events.TaskUpdated, id, stored[id])
+	}
+
+	h.logger.Info("Sync completed",
+		"inserted", len(outcome.Inserted),
+		"updated", len(outcome.Updated),
+		"conflicts", len(outcome.Conflicts),
+		"invalid", len(req.Tasks)-len(pending),
+	)
+
+	data, err = json.Marshal(batchResponse{Results: results})
+	if err != nil {
+		h.encodingFailed(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if len(outcome.Conflicts) > 0 || len(pending) < len(req.Tasks) {
+		status = http.StatusMultiStatus
+	}
+	h.write(w, status, data)
+}
+
+// checkSyncedBlockers holds pushed tasks to the blocker rules of an update:
+// changed blockers must exist and may not lead back to the task, and a task
+// may only arrive completed while its blockers are, unless it was stored
+// completed with the same blockers. It returns the rejection of each task
+// that breaks them, by ID. Tasks pushed together cannot block each other.
+func (h *TaskHandler) checkSyncedBlockers(ctx context.Context, pending []*database.Task) (map[uuid.UUID]*errors.APIError, error) {
+	var ids []uuid.UUID
+	for _, task := range pending {
+		if len(task.BlockedBy) > 0 {
+			ids = append(ids, task.ID)
+		}
+	}
+	stored, err := h.findTasks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rejected := make(map[uuid.UUID]*errors.APIError)
+	for _, task := range pending {
+		if len(task.BlockedBy) == 0 {
+			continue
+		}
+
+		before := stored[task.ID]
+		changed := before == nil || !slices.Equal(before.BlockedBy, task.BlockedBy)
+		var apiErr *errors.APIError
+		if changed {
+			apiErr, err = h.checkBlockers(ctx, task.ID, task.BlockedBy)
+		}
+		if err == nil && apiErr == nil && task.Completed && (changed || !before.Completed) {
+			apiErr, err = h.checkCompletable(ctx, task.BlockedBy)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if apiErr != nil {
+			rejected[task.ID] = apiErr
+		}
+	}
+	return rejected, nil
+}
+
+// syncedTask converts a validated pushed task, keeping the client's ID and
+// timestamps. completedAt falls back to updatedAt for a completed task.
+func syncedTask(item *tasks.SyncTask) *database.Task {
+	task := &database.Task{
+		ID:          uuid.MustParse(item.Id),
+		Title:       item.Title,
+		Description: item.Description,
+		Completed:   item.Completed,
+		AssigneeID:  item.AssigneeId,
+		CreatedAt:   item.CreatedAt.AsTime().Unix(),
+		UpdatedAt:   item.UpdatedAt.AsTime().Unix(),
+		BlockedBy:   parseBlockers(item.BlockedBy),
+	}
+	if task.Completed {
+		completedAt := task.UpdatedAt
+		if item.CompletedAt != nil {
+			completedAt = item.CompletedAt.AsTime().Unix()
+		}
+		task.CompletedAt = &completedAt
+	}
+	// Unlike on create, a past expiry is taken as is and soon removed
+	if item.ExpiresAt != nil {
+		expiresAt := item.ExpiresAt.AsTime().Truncate(time.Second)
+		task.ExpiresAt = &expiresAt
+	}
+	return task
+}
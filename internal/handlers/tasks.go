@@ -1,61 +1,268 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
 	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
 	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
 	"github.com/PinceredCoder/restGo/internal/database"
 	"github.com/PinceredCoder/restGo/internal/errors"
-	"github.com/PinceredCoder/restGo/internal/helpers"
+	"github.com/PinceredCoder/restGo/internal/events"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Clock supplies the current time for task timestamps. Tests replace it to
+// get deterministic createdAt and updatedAt values.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
 type TaskHandler struct {
 	db     database.Database
 	logger *slog.Logger
+	// defaultCompleted filters the list when the client omits ?completed= (nil lists all)
+	defaultCompleted  *bool
+	publisher         events.TaskEventPublisher
+	maxTitleLen       int
+	maxDescriptionLen int
+	// requiredFields must not be blank, on top of the proto rules
+	requiredFields []string
+	// discardUnknown ignores unknown request body members instead of rejecting them
+	discardUnknown bool
+	// emitUnset writes unset task fields as null or their zero value instead
+	// of leaving them out
+	emitUnset bool
+	// maxResults caps the list response; zero returns every match
+	maxResults int
+	// defaultSort orders lists without ?sort=; nil keeps the repository's
+	defaultSort []database.SortKey
+	// maxResponseBytes caps the encoded size of a list response; zero disables it
+	maxResponseBytes int
+	// notifier wakes long-polling list requests; nil disables ?wait=
+	notifier *events.ChangeNotifier
+	// seedToken guards the admin seed endpoint; empty disables it
+	seedToken string
+	clock     Clock
+}
+
+type TaskHandlerOption func(*TaskHandler)
+
+// WithDefaultCompleted sets the completed filter applied to the list when the
+// client does not pass one. nil lists every task.
+func WithDefaultCompleted(completed *bool) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.defaultCompleted = completed
+	}
+}
+
+// WithEventPublisher sets where task change events are sent. The default
+// discards them.
+func WithEventPublisher(publisher events.TaskEventPublisher) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.publisher = publisher
+	}
+}
+
+// WithFieldLimits sets the maximum title and description lengths in runes.
+// The defaults are 100 and 500.
+func WithFieldLimits(maxTitleLen, maxDescriptionLen int) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.maxTitleLen = maxTitleLen
+		h.maxDescriptionLen = maxDescriptionLen
+	}
+}
+
+// WithRequiredFields makes the named fields, "title" or "description",
+// required on create and update: blank values are rejected. Titles are
+// required regardless, but only this rejects blank ones.
+func WithRequiredFields(fields []string) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.requiredFields = fields
+	}
+}
+
+// WithDiscardUnknown makes request bodies with unknown members acceptable:
+// the members are ignored. By default they are rejected with 400, naming the
+// member, so a misspelt field never goes unnoticed.
+func WithDiscardUnknown(discard bool) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.discardUnknown = discard
+	}
+}
+
+// WithEmitUnset makes responses list unset task fields: timestamps such as
+// completedAt are written as null, empty fields such as the description as
+// their zero value. By default they are left out. Fields with explicit
+// presence, the assignee, are left out either way, and tasks trimmed with
+// ?fields= still contain only the selected fields.
+func WithEmitUnset(emit bool) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.emitUnset = emit
+	}
+}
+
+// WithMaxResults caps how many tasks a list request returns. A truncated list
+// is answered with 206 Partial Content. Zero, the default, disables the cap.
+func WithMaxResults(maxResults int) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.maxResults = maxResults
+	}
+}
+
+// WithDefaultSort orders task lists and exports that do not ask for an order
+// with ?sort=. Without it, the default, they are oldest first.
+func WithDefaultSort(sort []database.SortKey) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.defaultSort = sort
+	}
+}
+
+// WithMaxResponseBytes caps the encoded size of a list response, so a page of
+// tasks with long descriptions stays small. Tasks that do not fit are left
+// for the next page, and the response is 206 Partial Content with the offset
+// to continue from. The first task is always returned, however large. Zero,
+// the default, disables the cap.
+func WithMaxResponseBytes(maxBytes int) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.maxResponseBytes = maxBytes
+	}
 }
 
-func NewTaskHandler(db database.Database, logger *slog.Logger) *TaskHandler {
-	return &TaskHandler{
-		db:     db,
-		logger: logger,
+// WithChangeNotifier lets list requests with ?wait= hold on until a change.
+// The notifier must also receive the handler's events, for example through a
+// MultiPublisher given to WithEventPublisher.
+func WithChangeNotifier(notifier *events.ChangeNotifier) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.notifier = notifier
 	}
 }
 
+// WithClock sets the time source for task timestamps and events. The default
+// is the system clock.
+func WithClock(clock Clock) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.clock = clock
+	}
+}
+
+func NewTaskHandler(db database.Database, logger *slog.Logger, opts ...TaskHandlerOption) *TaskHandler {
+	h := &TaskHandler{
+		db:                db,
+		logger:            logger,
+		publisher:         events.NopPublisher{},
+		maxTitleLen:       100,
+		maxDescriptionLen: 500,
+		clock:             realClock{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
 func (h *TaskHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	h.logger.Info("Fetching all tasks")
+	query, apiErr := h.parseTaskQuery(r)
+	if apiErr != nil {
+		h.logger.Warn("Invalid list query", "error", apiErr.Message, "query", r.URL.RawQuery)
+		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
+		return
+	}
 
-	taskList, err := h.db.GetTaskRepository().FindAll(r.Context())
+	limit, offset, apiErr := parsePaging(r.URL.Query(), h.maxResults)
+	if apiErr != nil {
+		h.logger.Warn("Invalid paging parameters", "error", apiErr.Message, "query", r.URL.RawQuery)
+		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
+		return
+	}
 
-	if err != nil {
-		h.logger.Error("Failed to retrieve tasks from database", "error", err)
-		errors.RespondWithError(w, http.StatusInternalServerError,
-			errors.NewInternalError("Failed to retrieve tasks"))
+	wait, apiErr := parseWait(r.URL.Query())
+	if apiErr != nil {
+		h.logger.Warn("Invalid wait parameter", "error", apiErr.Message, "query", r.URL.RawQuery)
+		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
 		return
 	}
 
-	h.logger.Info("Successfully retrieved tasks", "count", len(taskList))
+	fields, storedFields, apiErr := parseFields(r.URL.Query())
+	if apiErr != nil {
+		h.logger.Warn("Invalid fields parameter", "error", apiErr.Message, "query", r.URL.RawQuery)
+		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
+		return
+	}
+	query.Fields = storedFields
+
+	h.logger.Info("Fetching all tasks", "limit", limit, "offset", offset, "wait", wait)
 
-	response := &tasks.ListTasksResponse{
-		Tasks: helpers.Map(taskList, func(t *database.Task) *tasks.Task { return t.ToProto() }),
+	query.Offset = offset
+	query.Limit = limit
+
+	// Only the server's cap truncates; a client's own limit is just a page size
+	capped := limit == 0 && h.maxResults > 0
+	if capped {
+		// One extra task tells a list that fills the cap from a truncated one
+		query.Limit = h.maxResults + 1
 	}
 
-	data, err := protojson.Marshal(response)
+	taskList, err := h.waitForTasks(r.Context(), query, wait)
+
+	if err != nil && r.Context().Err() != nil {
+		// Nobody is left to answer
+		h.logger.Info("Client went away while waiting for tasks")
+		return
+	}
 	if err != nil {
-		h.logger.Error("Failed to marshal response", "error", err)
-		errors.RespondWithError(w, http.StatusInternalServerError,
-			errors.NewInternalError("Failed to encode response"))
+		h.logger.Error("Failed to retrieve tasks from database", "error", err)
+		h.storageFailed(w, err, "Failed to retrieve tasks")
 		return
 	}
 
-	w.Write(data)
+	// A page never holds more than was asked for, whatever the backend returned
+	if query.Limit > 0 && len(taskList) > query.Limit {
+		h.logger.Warn("Repository returned more tasks than the limit", "limit", query.Limit, "count", len(taskList))
+		taskList = taskList[:query.Limit]
+	}
+
+	status := http.StatusOK
+	if capped && len(taskList) > h.maxResults {
+		taskList = taskList[:h.maxResults]
+		status = http.StatusPartialContent
+		w.Header().Set("X-Result-Truncated", "true")
+		w.Header().Set("X-Result-Limit", strconv.Itoa(h.maxResults))
+		h.logger.Warn("Task list truncated", "limit", h.maxResults)
+	}
+
+	if h.maxResponseBytes > 0 {
+		fit, err := h.fitResponseBytes(taskList, fields)
+		if err != nil {
+			h.encodingFailed(w, err)
+			return
+		}
+		if fit < len(taskList) {
+			taskList = taskList[:fit]
+			status = http.StatusPartialContent
+			w.Header().Set("X-Result-Truncated", "true")
+			w.Header().Set("X-Next-Offset", strconv.Itoa(offset+fit))
+			h.logger.Warn("Task list truncated by size", "max_bytes", h.maxResponseBytes, "count", fit)
+		}
+	}
+
+	h.logger.Info("Successfully retrieved tasks", "count", len(taskList))
+
+	h.writeTasks(w, r, status, taskList, fields)
 }
 
 func (h *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -65,26 +272,70 @@ func (h *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.logger.Warn("Failed to read request body", "error", err)
 		errors.RespondWithError(w, http.StatusBadRequest,
-			errors.NewBadRequestError("Failed to read request body"))
+			errors.NewBadRequestError("Failed to read request body").WithCode(errors.CodeUnreadableBody))
+		return
+	}
+
+	if isEmptyBody(data) {
+		h.logger.Warn("Empty create request body")
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Request body is required").WithCode(errors.CodeBodyRequired))
 		return
 	}
 
 	var req tasks.CreateTaskRequest
-	if err := protojson.Unmarshal(data, &req); err != nil {
+	if err := h.unmarshal(data, &req); err != nil {
 		h.logger.Warn("Invalid JSON format in request", "error", err)
-		errors.RespondWithError(w, http.StatusBadRequest,
-			errors.NewBadRequestError("Invalid JSON format"))
+		errors.RespondWithError(w, http.StatusBadRequest, h.convertUnmarshalError(err))
 		return
 	}
 
 	if err := req.Validate(); err != nil {
 		h.logger.Warn("Validation failed for create request", "error", err)
 		apiErr := h.convertValidationError(err)
-		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
 		return
 	}
 
-	now := timestamppb.Now().AsTime().Unix()
+	if apiErr := h.validateRequired(req.Title, req.Description); apiErr != nil {
+		h.logger.Warn("Validation failed for create request", "details", apiErr.Details)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	if apiErr := h.validateLengths(req.Title, req.Description); apiErr != nil {
+		h.logger.Warn("Validation failed for create request", "details", apiErr.Details)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	if apiErr := h.validateCharacters(req.Title, req.Description); apiErr != nil {
+		h.logger.Warn("Validation failed for create request", "details", apiErr.Details)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	expiresAt, apiErr := h.validateExpiry(req.ExpiresAt)
+	if apiErr != nil {
+		h.logger.Warn("Validation failed for create request", "details", apiErr.Details)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	blockedBy := parseBlockers(req.BlockedBy)
+	apiErr, err = h.checkBlockers(r.Context(), uuid.Nil, blockedBy)
+	if err != nil {
+		h.logger.Error("Failed to check task blockers", "error", err)
+		h.storageFailed(w, err, "Failed to create task")
+		return
+	}
+	if apiErr != nil {
+		h.logger.Warn("Validation failed for create request", "details", apiErr.Details)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	now := h.clock.Now().Unix()
 	taskID := uuid.New()
 
 	taskDb := &database.Task{
@@ -94,32 +345,84 @@ func (h *TaskHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Completed:   false,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		ExpiresAt:   expiresAt,
+		BlockedBy:   blockedBy,
 	}
 
 	if err := h.db.GetTaskRepository().Create(r.Context(), taskDb); err != nil {
 		h.logger.Error("Failed to create task in database", "error", err, "task_id", taskID)
-		errors.RespondWithError(w, http.StatusInternalServerError,
-			errors.NewInternalError("Failed to create task"))
+		h.storageFailed(w, err, "Failed to create task")
 		return
 	}
 
 	h.logger.Info("Task created successfully", "task_id", taskID, "title", taskDb.Title)
+	h.publish(r.Context(), events.TaskCreated, taskID, taskDb)
+
+	h.writeTask(w, r, http.StatusCreated, taskDb, nil)
+}
+
+func (h *TaskHandler) Lookup(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Warn("Failed to read lookup request body", "error", err)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Failed to read request body").WithCode(errors.CodeUnreadableBody))
+		return
+	}
 
-	response := &tasks.GetTaskResponse{
-		Task: taskDb.ToProto(),
+	var req tasks.LookupTasksRequest
+	if err := h.unmarshal(data, &req); err != nil {
+		h.logger.Warn("Invalid JSON format in lookup request", "error", err)
+		errors.RespondWithError(w, http.StatusBadRequest, h.convertUnmarshalError(err))
+		return
 	}
 
-	data, err = protojson.Marshal(response)
+	if err := req.Validate(); err != nil {
+		h.logger.Warn("Validation failed for lookup request", "error", err)
+		apiErr := h.convertValidationError(err)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	ids := make([]uuid.UUID, len(req.Ids))
+	for i, idStr := range req.Ids {
+		// Validate() has already checked the format
+		ids[i] = uuid.MustParse(idStr)
+	}
+
+	h.logger.Info("Looking up tasks by ID", "count", len(ids))
+
+	taskList, err := h.db.GetTaskRepository().FindByIDs(r.Context(), ids)
 	if err != nil {
-		h.logger.Error("Failed to marshal create response", "error", err, "task_id", taskID)
+		h.logger.Error("Failed to look up tasks in database", "error", err)
+		h.storageFailed(w, err, "Failed to retrieve tasks")
+		return
+	}
+
+	response := &tasks.LookupTasksResponse{
+		Found:   make(map[string]*tasks.Task, len(taskList)),
+		Missing: []string{},
+	}
+	for _, task := range taskList {
+		response.Found[task.ID.String()] = task.ToProto()
+	}
+	for _, id := range ids {
+		if _, ok := response.Found[id.String()]; !ok {
+			response.Missing = append(response.Missing, id.String())
+		}
+	}
+
+	h.logger.Info("Task lookup completed", "found", len(response.Found), "missing", len(response.Missing))
+
+	data, err = h.marshalOptions(nil).Marshal(response)
+	if err != nil {
+		h.logger.Error("Failed to marshal lookup response", "error", err)
 		errors.RespondWithError(w, http.StatusInternalServerError,
-			errors.NewInternalError("Failed to encode response"))
+			errors.NewInternalError("Failed to encode response").WithCode(errors.CodeEncodingFailure))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	w.Write(data)
+	h.write(w, http.StatusOK, data)
 }
 
 func (h *TaskHandler) GetByID(w http.ResponseWriter, r *http.Request) {
@@ -129,43 +432,70 @@ func (h *TaskHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.logger.Warn("Invalid task ID format", "id", idStr)
 		errors.RespondWithError(w, http.StatusBadRequest,
-			errors.NewBadRequestError("Invalid task ID format"))
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
+	}
+
+	fields, _, apiErr := parseFields(r.URL.Query())
+	if apiErr != nil {
+		h.logger.Warn("Invalid fields parameter", "error", apiErr.Message, "task_id", id)
+		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
 		return
 	}
 
 	h.logger.Info("Fetching task by ID", "task_id", id)
 
+	// Single tasks are not projected: the cache must hold them whole
+
 	taskDb, err := h.db.GetTaskRepository().FindByID(r.Context(), id)
 	if err != nil {
 		h.logger.Error("Failed to retrieve task from database", "error", err, "task_id", id)
-		errors.RespondWithError(w, http.StatusInternalServerError,
-			errors.NewInternalError("Failed to retrieve task"))
+		h.storageFailed(w, err, "Failed to retrieve task")
 		return
 	}
 
 	if taskDb == nil {
 		h.logger.Info("Task not found", "task_id", id)
 		errors.RespondWithError(w, http.StatusNotFound,
-			errors.NewNotFoundError("Task not found"))
+			errors.NewNotFoundError("Task not found").WithCode(errors.CodeTaskNotFound))
 		return
 	}
 
 	h.logger.Info("Task retrieved successfully", "task_id", id)
 
-	response := &tasks.GetTaskResponse{
-		Task: taskDb.ToProto(),
+	setLastModified(w, taskDb)
+	h.writeTask(w, r, http.StatusOK, taskDb, fields)
+}
+
+// Exists handles GET /api/v1/tasks/{id}/exists: 200 with {"exists":true} or
+// {"exists":false}, without loading the task.
+func (h *TaskHandler) Exists(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid task ID format for exists check", "id", idStr)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
 	}
 
-	data, err := protojson.Marshal(response)
+	exists, err := h.db.GetTaskRepository().Exists(r.Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to marshal GetByID response", "error", err, "task_id", id)
-		errors.RespondWithError(w, http.StatusInternalServerError,
-			errors.NewInternalError("Failed to encode response"))
+		h.logger.Error("Failed to check task exists in database", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to check task")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(data)
+	data, err := json.Marshal(struct {
+		Exists bool `json:"exists"`
+	}{exists})
+	if err != nil {
+		h.encodingFailed(w, err)
+		return
+	}
+
+	h.write(w, http.StatusOK, data)
 }
 
 func (h *TaskHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -175,7 +505,7 @@ func (h *TaskHandler) Update(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.logger.Warn("Invalid task ID format for update", "id", idStr)
 		errors.RespondWithError(w, http.StatusBadRequest,
-			errors.NewBadRequestError("Invalid task ID format"))
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
 		return
 	}
 
@@ -185,71 +515,331 @@ func (h *TaskHandler) Update(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.logger.Warn("Failed to read update request body", "error", err, "task_id", id)
 		errors.RespondWithError(w, http.StatusBadRequest,
-			errors.NewBadRequestError("Failed to read request body"))
+			errors.NewBadRequestError("Failed to read request body").WithCode(errors.CodeUnreadableBody))
+		return
+	}
+
+	if isEmptyBody(data) {
+		h.logger.Warn("Empty update request body", "task_id", id)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Request body is required").WithCode(errors.CodeBodyRequired))
 		return
 	}
 
 	var req tasks.UpdateTaskRequest
-	if err := protojson.Unmarshal(data, &req); err != nil {
+	if err := h.unmarshal(data, &req); err != nil {
 		h.logger.Warn("Invalid JSON format in update request", "error", err, "task_id", id)
-		errors.RespondWithError(w, http.StatusBadRequest,
-			errors.NewBadRequestError("Invalid JSON format"))
+		errors.RespondWithError(w, http.StatusBadRequest, h.convertUnmarshalError(err))
 		return
 	}
 
 	if err := req.Validate(); err != nil {
 		h.logger.Warn("Validation failed for update request", "error", err, "task_id", id)
 		apiErr := h.convertValidationError(err)
-		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	if apiErr := h.validateRequired(req.Title, req.GetDescription()); apiErr != nil {
+		h.logger.Warn("Validation failed for update request", "details", apiErr.Details, "task_id", id)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	if apiErr := h.validateLengths(req.Title, req.GetDescription()); apiErr != nil {
+		h.logger.Warn("Validation failed for update request", "details", apiErr.Details, "task_id", id)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	if apiErr := h.validateCharacters(req.Title, req.GetDescription()); apiErr != nil {
+		h.logger.Warn("Validation failed for update request", "details", apiErr.Details, "task_id", id)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	expiresAt, apiErr := h.validateExpiry(req.ExpiresAt)
+	if apiErr != nil {
+		h.logger.Warn("Validation failed for update request", "details", apiErr.Details, "task_id", id)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+	// Unlike the description, an expiry left out of the update is cleared
+	if expiresAt == nil {
+		expiresAt = &time.Time{}
+	}
+
+	// Blockers left out are cleared too
+	blockedBy := parseBlockers(req.BlockedBy)
+	apiErr, err = h.checkBlockers(r.Context(), id, blockedBy)
+
+	// A completed task only stays so if its blockers do not change. Without
+	// completed in the request the stored task says whether it is completed,
+	// so the write must then be made over that copy
+	var read *database.Task
+	if err == nil && apiErr == nil {
+		switch {
+		case req.GetCompleted():
+			apiErr, err = h.checkCompletable(r.Context(), blockedBy)
+		case req.Completed == nil:
+			read, err = h.db.GetTaskRepository().FindByID(r.Context(), id)
+			if err == nil && read != nil && read.Completed && !slices.Equal(blockedBy, read.BlockedBy) {
+				apiErr, err = h.checkCompletable(r.Context(), blockedBy)
+			}
+		}
+	}
+	if err != nil {
+		h.logger.Error("Failed to check task blockers", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to update task")
+		return
+	}
+	if apiErr != nil {
+		h.logger.Warn("Validation failed for update request", "details", apiErr.Details, "task_id", id)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	update := database.TaskUpdate{
+		Title:       &req.Title,
+		Description: req.Description,
+		Completed:   req.Completed,
+		ExpiresAt:   expiresAt,
+		BlockedBy:   &blockedBy,
+		UpdatedAt:   h.clock.Now().Unix(),
+	}
+	// An unparsable date is ignored, as RFC 9110 requires
+	if since, err := http.ParseTime(r.Header.Get("If-Unmodified-Since")); err == nil {
+		unmodifiedSince := since.Unix()
+		update.UnmodifiedSince = &unmodifiedSince
+	}
+	if read != nil {
+		if update.UnmodifiedSince != nil && read.UpdatedAt > *update.UnmodifiedSince {
+			h.respondUpdateMissed(w, r, id, update)
+			return
+		}
+		update.UnmodifiedSince = &read.UpdatedAt
+	}
+
+	// One atomic write, so a concurrent update can never be half overwritten
+	stored, err := h.db.GetTaskRepository().FindOneAndUpdate(r.Context(), id, update)
+	if err != nil {
+		h.logger.Error("Failed to update task in database", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to update task")
+		return
+	}
+	if stored == nil && read != nil {
+		// The client's precondition held for the copy read, so another write
+		// got in between
+		h.respondVersionMissed(w, r, id, "Task was modified while the update was applied")
+		return
+	}
+	if stored == nil {
+		h.respondUpdateMissed(w, r, id, update)
+		return
+	}
+
+	task := *stored
+	update.Apply(&task)
+
+	h.logger.Info("Task updated successfully", "task_id", id, "title", task.Title)
+	setLastModified(w, &task)
+	h.publishUpdate(r.Context(), id, stored, &task)
+
+	h.writeTask(w, r, http.StatusOK, &task, nil)
+}
+
+// Patch applies an RFC 6902 JSON Patch to a task. The patched task must pass
+// the same validation as a full update.
+func (h *TaskHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid task ID format for patch", "id", idStr)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != jsonPatchContentType {
+		h.logger.Warn("Unsupported patch content type", "content_type", r.Header.Get("Content-Type"), "task_id", id)
+		errors.RespondWithError(w, http.StatusUnsupportedMediaType,
+			errors.NewUnsupportedMediaTypeError("Content-Type must be "+jsonPatchContentType).WithCode(errors.CodeUnsupportedContentType))
+		return
+	}
+
+	h.logger.Info("Patching task", "task_id", id)
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Warn("Failed to read patch request body", "error", err, "task_id", id)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Failed to read request body").WithCode(errors.CodeUnreadableBody))
+		return
+	}
+
+	if isEmptyBody(data) {
+		h.logger.Warn("Empty patch request body", "task_id", id)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Request body is required").WithCode(errors.CodeBodyRequired))
+		return
+	}
+
+	var ops []jsonPatchOperation
+	if err := json.Unmarshal(data, &ops); err != nil {
+		h.logger.Warn("Invalid JSON Patch document", "error", err, "task_id", id)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Request body must be a JSON Patch array").WithCode(errors.CodeInvalidPatch))
 		return
 	}
 
 	task, err := h.db.GetTaskRepository().FindByID(r.Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to retrieve task for update", "error", err, "task_id", id)
-		errors.RespondWithError(w, http.StatusInternalServerError,
-			errors.NewInternalError("Failed to retrieve task"))
+		h.logger.Error("Failed to retrieve task for patch", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to retrieve task")
 		return
 	}
 	if task == nil {
-		h.logger.Info("Task not found for update", "task_id", id)
+		h.logger.Info("Task not found for patch", "task_id", id)
 		errors.RespondWithError(w, http.StatusNotFound,
-			errors.NewNotFoundError("Task not found"))
+			errors.NewNotFoundError("Task not found").WithCode(errors.CodeTaskNotFound))
 		return
 	}
 
-	task.Title = req.Title
-	task.Description = req.Description
+	doc, err := taskPatchDocument(task)
+	if err != nil {
+		h.logger.Error("Failed to build patch document", "error", err, "task_id", id)
+		errors.RespondWithError(w, http.StatusInternalServerError,
+			errors.NewInternalError("Failed to patch task").WithCode(errors.CodeEncodingFailure))
+		return
+	}
 
-	if req.Completed != nil {
-		task.Completed = *req.Completed
+	if err := applyJSONPatch(doc, ops); err != nil {
+		if stderrors.Is(err, errJSONPatchTestFailed) {
+			h.logger.Info("JSON Patch test failed", "error", err, "task_id", id)
+			errors.RespondWithError(w, http.StatusConflict,
+				errors.NewConflictError(err.Error()).WithCode(errors.CodeVersionConflict))
+			return
+		}
+		h.logger.Warn("Invalid JSON Patch operation", "error", err, "task_id", id)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError(err.Error()).WithCode(errors.CodeInvalidPatch))
+		return
 	}
 
-	task.UpdatedAt = timestamppb.Now().AsTime().Unix()
+	req, err := jsonPatchUpdateRequest(doc)
+	if err != nil {
+		h.logger.Warn("Patched task has invalid field types", "error", err, "task_id", id)
+		errors.RespondWithError(w, http.StatusBadRequest, h.convertUnmarshalError(err))
+		return
+	}
 
-	if err := h.db.GetTaskRepository().Update(r.Context(), id, task); err != nil {
-		h.logger.Error("Failed to update task in database", "error", err, "task_id", id)
-		errors.RespondWithError(w, http.StatusInternalServerError,
-			errors.NewInternalError("Failed to update task"))
+	if err := req.Validate(); err != nil {
+		h.logger.Warn("Validation failed for patched task", "error", err, "task_id", id)
+		apiErr := h.convertValidationError(err)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
 		return
 	}
 
-	h.logger.Info("Task updated successfully", "task_id", id, "title", task.Title)
+	if apiErr := h.validateRequired(req.Title, req.GetDescription()); apiErr != nil {
+		h.logger.Warn("Validation failed for patched task", "details", apiErr.Details, "task_id", id)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
 
-	response := &tasks.GetTaskResponse{
-		Task: task.ToProto(),
+	if apiErr := h.validateLengths(req.Title, req.GetDescription()); apiErr != nil {
+		h.logger.Warn("Validation failed for patched task", "details", apiErr.Details, "task_id", id)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
 	}
 
-	data, err = protojson.Marshal(response)
+	if apiErr := h.validateCharacters(req.Title, req.GetDescription()); apiErr != nil {
+		h.logger.Warn("Validation failed for patched task", "details", apiErr.Details, "task_id", id)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	blockedBy := parseBlockers(req.BlockedBy)
+	blockersChanged := !slices.Equal(blockedBy, task.BlockedBy)
+	var apiErr *errors.APIError
+	if blockersChanged {
+		apiErr, err = h.checkBlockers(r.Context(), id, blockedBy)
+	}
+	// A completed task only stays so if its blockers do not change
+	if err == nil && apiErr == nil && req.GetCompleted() && (!task.Completed || blockersChanged) {
+		apiErr, err = h.checkCompletable(r.Context(), blockedBy)
+	}
 	if err != nil {
-		h.logger.Error("Failed to marshal update response", "error", err, "task_id", id)
-		errors.RespondWithError(w, http.StatusInternalServerError,
-			errors.NewInternalError("Failed to encode response"))
+		h.logger.Error("Failed to check task blockers", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to update task")
+		return
+	}
+	if apiErr != nil {
+		h.logger.Warn("Validation failed for patched task", "details", apiErr.Details, "task_id", id)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(data)
+	description := req.GetDescription()
+	completed := req.GetCompleted()
+	update := database.TaskUpdate{
+		Title:       &req.Title,
+		Description: &description,
+		Completed:   &completed,
+		BlockedBy:   &blockedBy,
+		UpdatedAt:   h.clock.Now().Unix(),
+		// The patch, and its test operations, only hold for the copy read
+		// above; a write since then must not be overwritten
+		UnmodifiedSince: &task.UpdatedAt,
+	}
+
+	stored, err := h.db.GetTaskRepository().FindOneAndUpdate(r.Context(), id, update)
+	if err != nil {
+		h.logger.Error("Failed to update patched task in database", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to update task")
+		return
+	}
+	if stored == nil {
+		h.respondVersionMissed(w, r, id, "Task was modified while the patch was applied")
+		return
+	}
+
+	patched := *stored
+	update.Apply(&patched)
+
+	h.logger.Info("Task patched successfully", "task_id", id, "operations", len(ops))
+	h.publishUpdate(r.Context(), id, stored, &patched)
+
+	h.writeTask(w, r, http.StatusOK, &patched, nil)
+}
+
+// respondVersionMissed answers a write that found the task gone or changed
+// since it was read: 404 or 409, after which the client can read the task
+// again and retry.
+func (h *TaskHandler) respondVersionMissed(w http.ResponseWriter, r *http.Request, id uuid.UUID, message string) {
+	status, apiErr, err := h.missedError(r.Context(), id, message)
+	if err != nil {
+		h.logger.Error("Failed to check task exists in database", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to update task")
+		return
+	}
+
+	h.logger.Info("Write missed the task", "task_id", id, "status", status)
+	errors.RespondWithError(w, status, apiErr)
+}
+
+// missedError explains a write made conditional on the copy read before:
+// 404 if the task has been deleted since, 409 with message if it has been
+// modified.
+func (h *TaskHandler) missedError(ctx context.Context, id uuid.UUID, message string) (int, *errors.APIError, error) {
+	exists, err := h.db.GetTaskRepository().Exists(ctx, id)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !exists {
+		return http.StatusNotFound, errors.NewNotFoundError("Task not found").WithCode(errors.CodeTaskNotFound), nil
+	}
+	return http.StatusConflict, errors.NewConflictError(message).WithCode(errors.CodeVersionConflict), nil
 }
 
 func (h *TaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -259,7 +849,7 @@ func (h *TaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.logger.Warn("Invalid task ID format for delete", "id", idStr)
 		errors.RespondWithError(w, http.StatusBadRequest,
-			errors.NewBadRequestError("Invalid task ID format"))
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
 		return
 	}
 
@@ -267,12 +857,304 @@ func (h *TaskHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.db.GetTaskRepository().Delete(r.Context(), id); err != nil {
 		h.logger.Error("Failed to delete task from database", "error", err, "task_id", id)
-		errors.RespondWithError(w, http.StatusInternalServerError,
-			errors.NewInternalError("Failed to delete task"))
+		h.storageFailed(w, err, "Failed to delete task")
 		return
 	}
 
 	h.logger.Info("Task deleted successfully", "task_id", id)
+	h.publish(r.Context(), events.TaskDeleted, id, nil)
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (h *TaskHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid task ID format for assign", "id", idStr)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Warn("Failed to read assign request body", "error", err, "task_id", id)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Failed to read request body").WithCode(errors.CodeUnreadableBody))
+		return
+	}
+
+	var req tasks.AssignTaskRequest
+	if err := h.unmarshal(data, &req); err != nil {
+		h.logger.Warn("Invalid JSON format in assign request", "error", err, "task_id", id)
+		errors.RespondWithError(w, http.StatusBadRequest, h.convertUnmarshalError(err))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.logger.Warn("Validation failed for assign request", "error", err, "task_id", id)
+		apiErr := h.convertValidationError(err)
+		errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+		return
+	}
+
+	h.logger.Info("Assigning task", "task_id", id, "assignee_id", req.AssigneeId)
+
+	h.setAssignee(w, r, id, req.AssigneeId)
+}
+
+func (h *TaskHandler) Unassign(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid task ID format for unassign", "id", idStr)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
+	}
+
+	h.logger.Info("Unassigning task", "task_id", id)
+
+	h.setAssignee(w, r, id, "")
+}
+
+// setAssignee stores the assignee ("" clears it) and writes the updated task.
+// It is one atomic write, so the response and the event show the task as
+// stored, whatever else changed it meanwhile.
+func (h *TaskHandler) setAssignee(w http.ResponseWriter, r *http.Request, id uuid.UUID, assigneeID string) {
+	update := database.TaskUpdate{
+		AssigneeID: &assigneeID,
+		UpdatedAt:  h.clock.Now().Unix(),
+	}
+
+	stored, err := h.db.GetTaskRepository().FindOneAndUpdate(r.Context(), id, update)
+	if err != nil {
+		h.logger.Error("Failed to update task assignment in database", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to update task")
+		return
+	}
+	if stored == nil {
+		h.logger.Info("Task not found for assignment", "task_id", id)
+		errors.RespondWithError(w, http.StatusNotFound,
+			errors.NewNotFoundError("Task not found").WithCode(errors.CodeTaskNotFound))
+		return
+	}
+
+	updated := *stored
+	update.Apply(&updated)
+
+	h.logger.Info("Task assignment updated successfully", "task_id", id)
+	h.publishUpdate(r.Context(), id, stored, &updated)
+
+	h.writeTask(w, r, http.StatusOK, &updated, nil)
+}
+
+func (h *TaskHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid task ID format for archive", "id", idStr)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
+	}
+
+	h.logger.Info("Archiving task", "task_id", id)
+
+	h.setArchived(w, r, id, true)
+}
+
+func (h *TaskHandler) Unarchive(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid task ID format for unarchive", "id", idStr)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
+	}
+
+	h.logger.Info("Unarchiving task", "task_id", id)
+
+	h.setArchived(w, r, id, false)
+}
+
+// setArchived stores the archived flag and writes the updated task.
+func (h *TaskHandler) setArchived(w http.ResponseWriter, r *http.Request, id uuid.UUID, archived bool) {
+	update := database.TaskUpdate{
+		Archived:  &archived,
+		UpdatedAt: h.clock.Now().Unix(),
+	}
+
+	// One atomic write, so the response and the event show the stored task
+	stored, err := h.db.GetTaskRepository().FindOneAndUpdate(r.Context(), id, update)
+	if err != nil {
+		h.logger.Error("Failed to update task archived flag in database", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to update task")
+		return
+	}
+	if stored == nil {
+		h.logger.Info("Task not found for archiving", "task_id", id)
+		errors.RespondWithError(w, http.StatusNotFound,
+			errors.NewNotFoundError("Task not found").WithCode(errors.CodeTaskNotFound))
+		return
+	}
+
+	updated := *stored
+	update.Apply(&updated)
+
+	h.logger.Info("Task archived flag updated successfully", "task_id", id, "archived", archived)
+	h.publishUpdate(r.Context(), id, stored, &updated)
+
+	h.writeTask(w, r, http.StatusOK, &updated, nil)
+}
+
+// Complete handles POST /api/v1/tasks/{id}/complete
+func (h *TaskHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid task ID format for complete", "id", idStr)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
+	}
+
+	h.logger.Info("Completing task", "task_id", id)
+
+	h.setCompleted(w, r, id, true)
+}
+
+// Reopen handles POST /api/v1/tasks/{id}/reopen
+func (h *TaskHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.logger.Warn("Invalid task ID format for reopen", "id", idStr)
+		errors.RespondWithError(w, http.StatusBadRequest,
+			errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+		return
+	}
+
+	h.logger.Info("Reopening task", "task_id", id)
+
+	h.setCompleted(w, r, id, false)
+}
+
+func (h *TaskHandler) setCompleted(w http.ResponseWriter, r *http.Request, id uuid.UUID, completed bool) {
+	update := database.TaskUpdate{
+		Completed: &completed,
+		UpdatedAt: h.clock.Now().Unix(),
+	}
+
+	if completed {
+		task, err := h.db.GetTaskRepository().FindByID(r.Context(), id)
+		if err != nil {
+			h.logger.Error("Failed to retrieve task for completion", "error", err, "task_id", id)
+			h.storageFailed(w, err, "Failed to retrieve task")
+			return
+		}
+		if task == nil {
+			h.logger.Info("Task not found for completion", "task_id", id)
+			errors.RespondWithError(w, http.StatusNotFound,
+				errors.NewNotFoundError("Task not found").WithCode(errors.CodeTaskNotFound))
+			return
+		}
+
+		apiErr, err := h.checkCompletable(r.Context(), task.BlockedBy)
+		if err != nil {
+			h.logger.Error("Failed to check task blockers", "error", err, "task_id", id)
+			h.storageFailed(w, err, "Failed to update task")
+			return
+		}
+		if apiErr != nil {
+			h.logger.Warn("Task is blocked", "details", apiErr.Details, "task_id", id)
+			errors.RespondWithError(w, rejectionStatus(apiErr), apiErr)
+			return
+		}
+
+		// The blockers were checked on this copy; new ones must not slip in
+		update.UnmodifiedSince = &task.UpdatedAt
+	}
+
+	stored, err := h.db.GetTaskRepository().FindOneAndUpdate(r.Context(), id, update)
+	if err != nil {
+		h.logger.Error("Failed to update task completed flag in database", "error", err, "task_id", id)
+		h.storageFailed(w, err, "Failed to update task")
+		return
+	}
+	if stored == nil {
+		h.respondVersionMissed(w, r, id, "Task was modified while it was being completed")
+		return
+	}
+
+	updated := *stored
+	update.Apply(&updated)
+
+	h.logger.Info("Task completed flag updated successfully", "task_id", id, "completed", completed)
+	h.publishUpdate(r.Context(), id, stored, &updated)
+
+	h.writeTask(w, r, http.StatusOK, &updated, nil)
+}
+
+// respondUpdateMissed answers an update that matched no task: 412 if the task
+// exists, so only If-Unmodified-Since can have excluded it, and 404 otherwise.
+func (h *TaskHandler) respondUpdateMissed(w http.ResponseWriter, r *http.Request, id uuid.UUID, update database.TaskUpdate) {
+	if update.UnmodifiedSince != nil {
+		exists, err := h.db.GetTaskRepository().Exists(r.Context(), id)
+		if err != nil {
+			h.logger.Error("Failed to check task exists in database", "error", err, "task_id", id)
+			h.storageFailed(w, err, "Failed to update task")
+			return
+		}
+		if exists {
+			h.logger.Info("Task modified since the client's copy", "task_id", id)
+			errors.RespondWithError(w, http.StatusPreconditionFailed,
+				errors.NewPreconditionFailedError("Task was modified after If-Unmodified-Since").WithCode(errors.CodeTaskModified))
+			return
+		}
+	}
+
+	h.logger.Info("Task not found for update", "task_id", id)
+	errors.RespondWithError(w, http.StatusNotFound,
+		errors.NewNotFoundError("Task not found").WithCode(errors.CodeTaskNotFound))
+}
+
+// setLastModified sets Last-Modified from the task's updatedAt, the value
+// If-Unmodified-Since is compared against.
+func setLastModified(w http.ResponseWriter, task *database.Task) {
+	w.Header().Set("Last-Modified", time.Unix(task.UpdatedAt, 0).UTC().Format(http.TimeFormat))
+}
+
+// publish reports a persisted change. The event gets its own copy of the task
+// so sinks never observe later mutations.
+func (h *TaskHandler) publish(ctx context.Context, eventType events.EventType, id uuid.UUID, task *database.Task) {
+	h.publishEvent(ctx, events.TaskEvent{Type: eventType, TaskID: id, Task: task})
+}
+
+// publishUpdate reports a persisted update along with the fields it changed
+// from before.
+func (h *TaskHandler) publishUpdate(ctx context.Context, id uuid.UUID, before, after *database.Task) {
+	h.publishEvent(ctx, events.TaskEvent{
+		Type:    events.TaskUpdated,
+		TaskID:  id,
+		Task:    after,
+		Changes: events.Diff(before, after),
+	})
+}
+
+func (h *TaskHandler) publishEvent(ctx context.Context, event events.TaskEvent) {
+	event.OccurredAt = h.clock.Now()
+	if event.Task != nil {
+		snapshot := *event.Task
+		event.Task = &snapshot
+	}
+	h.publisher.Publish(ctx, event)
+}
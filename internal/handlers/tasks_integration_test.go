@@ -2,35 +2,76 @@ package handlers
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
 	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
+	"github.com/PinceredCoder/restGo/internal/events"
+	"github.com/PinceredCoder/restGo/internal/middleware"
 	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 // setupRouter creates a chi router with task handler routes
 // This allows us to test with URL parameters properly
 func setupRouter() (*chi.Mux, *TaskHandler) {
 	r := chi.NewRouter()
+	r.NotFound(NotFound)
+	r.MethodNotAllowed(MethodNotAllowed)
+	r.Use(chimiddleware.StripSlashes)
+
 	mockDB := NewMockDatabase()
 	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
 		Level: slog.LevelError, // Only log errors in tests
 	}))
 	h := NewTaskHandler(mockDB, logger)
 
+	r.Post("/api/v1/admin/seed", h.Seed)
+	r.Post("/api/v1/sync", h.Sync)
 	r.Get("/api/v1/tasks", h.GetAll)
+	r.Head("/api/v1/tasks", Head(h.GetAll))
 	r.Post("/api/v1/tasks", h.Create)
+	r.Post("/api/v1/tasks/lookup", h.Lookup)
+	r.Post("/api/v1/tasks/batch", h.CreateBatch)
+	r.Patch("/api/v1/tasks/batch", h.PatchBatch)
+	r.Get("/api/v1/tasks/export", h.Export)
+	r.Get("/api/v1/tasks/schema", h.Schema)
+	r.Get("/api/v1/tasks/count-by", h.CountBy)
+	r.Get("/api/v1/tasks/completion-trend", h.CompletionTrend)
 	r.Get("/api/v1/tasks/{id}", h.GetByID)
+	r.Head("/api/v1/tasks/{id}", Head(h.GetByID))
 	r.Put("/api/v1/tasks/{id}", h.Update)
+	r.Patch("/api/v1/tasks/{id}", h.Patch)
 	r.Delete("/api/v1/tasks/{id}", h.Delete)
+	r.Get("/api/v1/tasks/{id}/exists", h.Exists)
+	r.Get("/api/v1/tasks/{id}/next", h.Next)
+	r.Get("/api/v1/tasks/{id}/prev", h.Prev)
+	r.Get("/api/v1/tasks/{id}/blockers", h.Blockers)
+	r.Post("/api/v1/tasks/{id}/assign", h.Assign)
+	r.Post("/api/v1/tasks/{id}/unassign", h.Unassign)
+	r.Post("/api/v1/tasks/{id}/archive", h.Archive)
+	r.Post("/api/v1/tasks/{id}/unarchive", h.Unarchive)
+	r.Post("/api/v1/tasks/{id}/complete", h.Complete)
+	r.Post("/api/v1/tasks/{id}/reopen", h.Reopen)
 
 	return r, h
 }
@@ -138,7 +179,7 @@ func TestIntegrationUpdate(t *testing.T) {
 	// Update the task
 	updateReq := &tasks.UpdateTaskRequest{
 		Title:       "Updated Title",
-		Description: "Updated Description",
+		Description: proto.String("Updated Description"),
 	}
 
 	bodyBytes, _ := protojson.Marshal(updateReq)
@@ -186,7 +227,7 @@ func TestIntegrationUpdateCompleted(t *testing.T) {
 	completed := true
 	updateReq := &tasks.UpdateTaskRequest{
 		Title:       "Task to Complete",
-		Description: "Description",
+		Description: proto.String("Description"),
 		Completed:   &completed,
 	}
 
@@ -210,6 +251,57 @@ func TestIntegrationUpdateCompleted(t *testing.T) {
 	}
 }
 
+// TestIntegrationUpdateIgnoresCreatedAt tests that neither PUT nor PATCH can
+// move createdAt or change the ID
+func TestIntegrationUpdateIgnoresCreatedAt(t *testing.T) {
+	router, h := setupRouter()
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440007")
+	taskID := taskUUID.String()
+
+	dbTask := &database.Task{
+		ID:          taskUUID,
+		Title:       "Original Title",
+		Description: "Original Description",
+		CreatedAt:   1234567890,
+		UpdatedAt:   1234567890,
+	}
+	h.db.GetTaskRepository().Create(context.Background(), dbTask)
+
+	requests := []struct {
+		method      string
+		contentType string
+		body        string
+	}{
+		{http.MethodPut, "application/json", `{"title":"Updated Title","description":"Updated"}`},
+		{http.MethodPut, "application/json", `{"title":"Updated Title","description":"Updated","createdAt":"2030-01-01T00:00:00Z"}`},
+		{http.MethodPatch, jsonPatchContentType, `[{"op":"replace","path":"/title","value":"Patched"}]`},
+		{http.MethodPatch, jsonPatchContentType, `[{"op":"replace","path":"/createdAt","value":"2030-01-01T00:00:00Z"}]`},
+		{http.MethodPatch, jsonPatchContentType, `[{"op":"replace","path":"/id","value":"` + uuid.New().String() + `"}]`},
+	}
+
+	for _, tt := range requests {
+		req := httptest.NewRequest(tt.method, "/api/v1/tasks/"+taskID, bytes.NewReader([]byte(tt.body)))
+		req.Header.Set("Content-Type", tt.contentType)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		stored, _ := h.db.GetTaskRepository().FindByID(context.Background(), taskUUID)
+		if stored == nil {
+			t.Fatalf("task moved by %s %s", tt.method, tt.body)
+		}
+		if stored.CreatedAt != 1234567890 {
+			t.Errorf("createdAt changed to %d by %s %s", stored.CreatedAt, tt.method, tt.body)
+		}
+	}
+
+	stored, _ := h.db.GetTaskRepository().FindByID(context.Background(), taskUUID)
+	if stored.Title != "Patched" {
+		t.Errorf("expected the patches to apply, got title %q", stored.Title)
+	}
+}
+
 // TestIntegrationDelete tests deleting a task
 func TestIntegrationDelete(t *testing.T) {
 	router, h := setupRouter()
@@ -297,7 +389,7 @@ func TestIntegrationFullWorkflow(t *testing.T) {
 	completed := true
 	updateReq := &tasks.UpdateTaskRequest{
 		Title:       "Updated Workflow Task",
-		Description: "Updated description",
+		Description: proto.String("Updated description"),
 		Completed:   &completed,
 	}
 
@@ -363,3 +455,3005 @@ func TestIntegrationFullWorkflow(t *testing.T) {
 		t.Error("deleted task should return 404")
 	}
 }
+
+// TestIntegrationAssign tests assigning and unassigning a task
+func TestIntegrationAssign(t *testing.T) {
+	router, h := setupRouter()
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440005")
+	taskID := taskUUID.String()
+
+	dbTask := &database.Task{
+		ID:          taskUUID,
+		Title:       "Task to Assign",
+		Description: "Description",
+		Completed:   false,
+		CreatedAt:   1234567890,
+		UpdatedAt:   1234567890,
+	}
+	h.db.GetTaskRepository().Create(context.Background(), dbTask)
+
+	body := []byte(`{"assigneeId":"alice"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID+"/assign", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response tasks.GetTaskResponse
+	if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Task.GetAssigneeId() != "alice" {
+		t.Errorf("expected assignee 'alice', got '%s'", response.Task.GetAssigneeId())
+	}
+
+	if response.Task.UpdatedAt.AsTime().Unix() == 1234567890 {
+		t.Error("expected updatedAt to be bumped")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskID+"/unassign", nil)
+	w = httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	response.Reset()
+	if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if response.Task.AssigneeId != nil {
+		t.Errorf("expected no assignee, got '%s'", response.Task.GetAssigneeId())
+	}
+}
+
+// TestIntegrationAssignValidation tests assignee identifier validation
+func TestIntegrationAssignValidation(t *testing.T) {
+	router, _ := setupRouter()
+
+	tests := []struct {
+		name       string
+		taskID     string
+		body       string
+		wantStatus int
+	}{
+		{"invalid assignee", "550e8400-e29b-41d4-a716-446655440006", `{"assigneeId":"bad id!"}`, http.StatusUnprocessableEntity},
+		{"empty assignee", "550e8400-e29b-41d4-a716-446655440006", `{}`, http.StatusUnprocessableEntity},
+		{"invalid task ID", "not-a-uuid", `{"assigneeId":"alice"}`, http.StatusBadRequest},
+		{"task not found", "550e8400-e29b-41d4-a716-446655440006", `{"assigneeId":"alice"}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/"+tt.taskID+"/assign", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestIntegrationGetAllAssigneeFilter tests filtering the list by assignee
+func TestIntegrationGetAllAssigneeFilter(t *testing.T) {
+	router, h := setupRouter()
+
+	alice, bob := "alice", "bob"
+	for _, assignee := range []*string{&alice, &bob, nil} {
+		h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+			ID:         uuid.New(),
+			Title:      "Task",
+			AssigneeID: assignee,
+			CreatedAt:  1234567890,
+			UpdatedAt:  1234567890,
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks?assignee=alice", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response tasks.ListTasksResponse
+	if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if len(response.Tasks) != 1 || response.Tasks[0].GetAssigneeId() != "alice" {
+		t.Errorf("expected only alice's task, got %d tasks", len(response.Tasks))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/tasks?assignee=bad%20id", nil)
+	w = httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid assignee, got %d", w.Code)
+	}
+}
+
+// TestIntegrationGetAllCreatedRange tests filtering the list by creation time
+func TestIntegrationGetAllCreatedRange(t *testing.T) {
+	router, h := setupRouter()
+
+	for _, createdAt := range []int64{1000, 2000, 3000} {
+		h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+			ID:        uuid.New(),
+			Title:     "Task",
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		})
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantCount  int
+	}{
+		{"inclusive range", "?created_from=1000&created_to=2000", http.StatusOK, 2},
+		{"from only", "?created_from=2500", http.StatusOK, 1},
+		{"to only", "?created_to=999", http.StatusOK, 0},
+		{"combined with assignee", "?created_from=1000&assignee=alice", http.StatusOK, 0},
+		{"non-numeric", "?created_from=yesterday", http.StatusBadRequest, 0},
+		{"from after to", "?created_from=3000&created_to=1000", http.StatusBadRequest, 0},
+		{"rfc 3339 bounds", "?created_from=1970-01-01T00:16:40Z&created_to=1970-01-01T00:33:20Z", http.StatusOK, 2},
+		{"rfc 3339 with offset", "?created_from=1970-01-01T01:41:40%2B01:00", http.StatusOK, 1},
+		{"mixed forms", "?created_from=1000&created_to=1970-01-01T00:16:40Z", http.StatusOK, 1},
+		{"date without time", "?created_from=1970-01-01", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response tasks.ListTasksResponse
+			if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if len(response.Tasks) != tt.wantCount {
+				t.Errorf("expected %d tasks, got %d", tt.wantCount, len(response.Tasks))
+			}
+		})
+	}
+}
+
+// TestIntegrationRequestTimeout tests that a slow repository trips the request timeout
+func TestIntegrationRequestTimeout(t *testing.T) {
+	mockDB := NewMockDatabase()
+	mockDB.taskRepo.delay = 5 * time.Second
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(mockDB, logger)
+
+	r := chi.NewRouter()
+	r.With(middleware.Timeout(50*time.Millisecond)).Get("/api/v1/tasks", h.GetAll)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+	w := httptest.NewRecorder()
+
+	start := time.Now()
+	r.ServeHTTP(w, req)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %v, expected it to be cut off by the timeout", elapsed)
+	}
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %s", contentType)
+	}
+
+	var apiErr errors.APIError
+	if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil {
+		t.Fatalf("failed to unmarshal error: %v", err)
+	}
+
+	if apiErr.Type != errors.ErrorTypeTimeout {
+		t.Errorf("expected error type %s, got %s", errors.ErrorTypeTimeout, apiErr.Type)
+	}
+}
+
+// TestIntegrationLookup tests fetching a set of tasks keyed by ID
+func TestIntegrationLookup(t *testing.T) {
+	router, h := setupRouter()
+
+	existing := uuid.MustParse("550e8400-e29b-41d4-a716-446655440008")
+	missing := uuid.MustParse("550e8400-e29b-41d4-a716-999999999997")
+
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:        existing,
+		Title:     "Existing Task",
+		CreatedAt: 1234567890,
+		UpdatedAt: 1234567890,
+	})
+
+	body := []byte(`{"ids":["` + existing.String() + `","` + missing.String() + `"]}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/lookup", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response tasks.LookupTasksResponse
+	if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if task, ok := response.Found[existing.String()]; !ok || task.Title != "Existing Task" {
+		t.Errorf("expected %s in found, got %v", existing, response.Found)
+	}
+
+	if len(response.Missing) != 1 || response.Missing[0] != missing.String() {
+		t.Errorf("expected missing [%s], got %v", missing, response.Missing)
+	}
+}
+
+// TestIntegrationLookupValidation tests ID and batch size validation
+func TestIntegrationLookupValidation(t *testing.T) {
+	router, _ := setupRouter()
+
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = `"` + uuid.New().String() + `"`
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"empty list", `{"ids":[]}`},
+		{"invalid ID", `{"ids":["not-a-uuid"]}`},
+		{"duplicate IDs", `{"ids":["550e8400-e29b-41d4-a716-446655440008","550e8400-e29b-41d4-a716-446655440008"]}`},
+		{"too many IDs", `{"ids":[` + strings.Join(tooMany, ",") + `]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/lookup", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status 422, got %d", w.Code)
+			}
+		})
+	}
+}
+
+// TestIntegrationMethodNotAllowed tests that disabled methods return a JSON 405
+func TestIntegrationMethodNotAllowed(t *testing.T) {
+	_, h := setupRouter()
+
+	r := chi.NewRouter()
+	r.MethodNotAllowed(MethodNotAllowed)
+	r.Get("/api/v1/tasks/{id}", h.GetByID)
+	r.Delete("/api/v1/tasks/{id}", MethodNotAllowed)
+
+	for _, method := range []string{http.MethodDelete, http.MethodPut} {
+		req := httptest.NewRequest(method, "/api/v1/tasks/550e8400-e29b-41d4-a716-446655440000", nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s: expected status 405, got %d", method, w.Code)
+		}
+
+		var apiErr errors.APIError
+		if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil {
+			t.Fatalf("%s: failed to unmarshal error: %v", method, err)
+		}
+
+		if apiErr.Type != errors.ErrorTypeMethod {
+			t.Errorf("%s: expected error type %s, got %s", method, errors.ErrorTypeMethod, apiErr.Type)
+		}
+	}
+}
+
+// TestIntegrationUnknownRoutes tests that routing errors use the JSON error format
+func TestIntegrationUnknownRoutes(t *testing.T) {
+	router, _ := setupRouter()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantType   errors.ErrorType
+	}{
+		{"unknown path", http.MethodGet, "/api/v1/projects", http.StatusNotFound, errors.ErrorTypeNotFound},
+		{"wrong method", http.MethodPut, "/api/v1/tasks", http.StatusMethodNotAllowed, errors.ErrorTypeMethod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			if contentType := w.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("expected Content-Type application/json, got %s", contentType)
+			}
+
+			var apiErr errors.APIError
+			if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil {
+				t.Fatalf("failed to unmarshal error: %v", err)
+			}
+
+			if apiErr.Type != tt.wantType {
+				t.Errorf("expected error type %s, got %s", tt.wantType, apiErr.Type)
+			}
+		})
+	}
+}
+
+// TestIntegrationJSONPatch tests applying RFC 6902 patches to a task
+func TestIntegrationJSONPatch(t *testing.T) {
+	router, h := setupRouter()
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440009")
+	taskID := taskUUID.String()
+
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:          taskUUID,
+		Title:       "Original Title",
+		Description: "Original Description",
+		CreatedAt:   1234567890,
+		UpdatedAt:   1234567890,
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		patch       string
+		wantStatus  int
+	}{
+		{"wrong content type", "application/json", `[]`, http.StatusUnsupportedMediaType},
+		{"not an array", jsonPatchContentType, `{"op":"replace"}`, http.StatusBadRequest},
+		{"read-only field", jsonPatchContentType, `[{"op":"replace","path":"/createdAt","value":"2030-01-01T00:00:00Z"}]`, http.StatusBadRequest},
+		{"invalid result", jsonPatchContentType, `[{"op":"replace","path":"/title","value":""}]`, http.StatusUnprocessableEntity},
+		{"wrong value type", jsonPatchContentType, `[{"op":"replace","path":"/completed","value":"yes"}]`, http.StatusBadRequest},
+		{"stale test", jsonPatchContentType, `[{"op":"test","path":"/title","value":"Stale Title"},{"op":"replace","path":"/title","value":"Lost Update"}]`, http.StatusConflict},
+		{"success", jsonPatchContentType, `[{"op":"test","path":"/title","value":"Original Title"},{"op":"replace","path":"/title","value":"Patched Title"},{"op":"replace","path":"/completed","value":true}]`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/tasks/"+taskID, bytes.NewReader([]byte(tt.patch)))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	stored, _ := h.db.GetTaskRepository().FindByID(context.Background(), taskUUID)
+	if stored.Title != "Patched Title" || !stored.Completed {
+		t.Errorf("expected patched task, got title '%s' completed %v", stored.Title, stored.Completed)
+	}
+
+	if stored.Description != "Original Description" || stored.CreatedAt != 1234567890 {
+		t.Error("untouched fields should be preserved")
+	}
+}
+
+// interleavingRepository runs between once after the next FindByID or
+// FindByIDs has read its tasks, letting a test land a write between a
+// handler's read and write
+type interleavingRepository struct {
+	*MockTaskRepository
+	between func()
+}
+
+func (r *interleavingRepository) FindByID(ctx context.Context, id uuid.UUID) (*database.Task, error) {
+	task, err := r.MockTaskRepository.FindByID(ctx, id)
+	r.interleave()
+	return task, err
+}
+
+func (r *interleavingRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*database.Task, error) {
+	tasks, err := r.MockTaskRepository.FindByIDs(ctx, ids)
+	r.interleave()
+	return tasks, err
+}
+
+func (r *interleavingRepository) interleave() {
+	if between := r.between; between != nil {
+		r.between = nil
+		between()
+	}
+}
+
+type interleavingDatabase struct {
+	*MockDatabase
+	repo *interleavingRepository
+}
+
+func (d *interleavingDatabase) GetTaskRepository() database.TaskRepository { return d.repo }
+
+// TestIntegrationJSONPatchRace tests that a patch landing between another
+// patch's read and write is not overwritten
+func TestIntegrationJSONPatchRace(t *testing.T) {
+	mockDB := NewMockDatabase()
+	repo := &interleavingRepository{MockTaskRepository: mockDB.taskRepo}
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(&interleavingDatabase{MockDatabase: mockDB, repo: repo}, logger, WithClock(clock))
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440048")
+	mockDB.taskRepo.Create(context.Background(), &database.Task{
+		ID:          taskUUID,
+		Title:       "Original Title",
+		Description: "Original Description",
+		CreatedAt:   1699999000,
+		UpdatedAt:   1699999000,
+	})
+
+	patch := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/tasks/"+taskUUID.String(), strings.NewReader(body))
+		req.Header.Set("Content-Type", jsonPatchContentType)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", taskUUID.String())
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		h.Patch(w, req)
+		return w
+	}
+
+	var second *httptest.ResponseRecorder
+	repo.between = func() {
+		clock.Advance(time.Second)
+		second = patch(`[{"op":"replace","path":"/description","value":"Second Writer"}]`)
+	}
+	first := patch(`[{"op":"test","path":"/description","value":"Original Description"},{"op":"replace","path":"/title","value":"First Writer"}]`)
+
+	if second == nil || second.Code != http.StatusOK {
+		t.Fatalf("expected the interleaved patch to succeed, got %v", second)
+	}
+	if first.Code != http.StatusConflict {
+		t.Fatalf("expected the overtaken patch to get 409, got %d: %s", first.Code, first.Body.String())
+	}
+
+	stored, _ := mockDB.taskRepo.FindByID(context.Background(), taskUUID)
+	if stored.Title != "Original Title" || stored.Description != "Second Writer" {
+		t.Errorf("expected only the interleaved patch to be saved, got title %q description %q", stored.Title, stored.Description)
+	}
+}
+
+// TestIntegrationCompleteRace tests that completing a task does not go
+// through when blockers are added between its check and its write
+func TestIntegrationCompleteRace(t *testing.T) {
+	mockDB := NewMockDatabase()
+	repo := &interleavingRepository{MockTaskRepository: mockDB.taskRepo}
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(&interleavingDatabase{MockDatabase: mockDB, repo: repo}, logger, WithClock(clock))
+
+	blocker := uuid.MustParse("550e8400-e29b-41d4-a716-446655440059")
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440060")
+	for _, id := range []uuid.UUID{blocker, taskUUID} {
+		mockDB.taskRepo.Create(context.Background(), &database.Task{
+			ID:        id,
+			Title:     "Open",
+			CreatedAt: 1699999000,
+			UpdatedAt: 1699999000,
+		})
+	}
+
+	repo.between = func() {
+		clock.Advance(time.Second)
+		mockDB.taskRepo.FindOneAndUpdate(context.Background(), taskUUID, database.TaskUpdate{
+			BlockedBy: &[]uuid.UUID{blocker},
+			UpdatedAt: clock.Now().Unix(),
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/"+taskUUID.String()+"/complete", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", taskUUID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+	h.Complete(w, req)
+
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), string(errors.CodeVersionConflict)) {
+		t.Fatalf("expected 409 %s, got %d: %s", errors.CodeVersionConflict, w.Code, w.Body.String())
+	}
+
+	stored, _ := mockDB.taskRepo.FindByID(context.Background(), taskUUID)
+	if stored.Completed {
+		t.Error("expected the newly blocked task to stay open")
+	}
+}
+
+// TestIntegrationGetAllCompletedFilter tests the completed filter and its configured default
+func TestIntegrationGetAllCompletedFilter(t *testing.T) {
+	mockDB := NewMockDatabase()
+	for _, completed := range []bool{true, false, false} {
+		mockDB.taskRepo.Create(context.Background(), &database.Task{
+			ID:        uuid.New(),
+			Title:     "Task",
+			Completed: completed,
+			CreatedAt: 1234567890,
+			UpdatedAt: 1234567890,
+		})
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	open := false
+
+	tests := []struct {
+		name       string
+		defaultTo  *bool
+		query      string
+		wantStatus int
+		wantCount  int
+	}{
+		{"no default", nil, "", http.StatusOK, 3},
+		{"explicit filter", nil, "?completed=true", http.StatusOK, 1},
+		{"open by default", &open, "", http.StatusOK, 2},
+		{"explicit overrides default", &open, "?completed=true", http.StatusOK, 1},
+		{"all overrides default", &open, "?completed=all", http.StatusOK, 3},
+		{"invalid value", nil, "?completed=maybe", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskHandler(mockDB, logger, WithDefaultCompleted(tt.defaultTo))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			h.GetAll(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response tasks.ListTasksResponse
+			if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if len(response.Tasks) != tt.wantCount {
+				t.Errorf("expected %d tasks, got %d", tt.wantCount, len(response.Tasks))
+			}
+		})
+	}
+}
+
+// TestIntegrationGetAllDescriptionFilter tests that has_description selects
+// tasks with or without a description
+func TestIntegrationGetAllDescriptionFilter(t *testing.T) {
+	mockDB := NewMockDatabase()
+	describedID := uuid.New()
+	bareID := uuid.New()
+	for i, task := range []*database.Task{
+		{ID: describedID, Title: "Described", Description: "Has one"},
+		{ID: bareID, Title: "Bare"},
+	} {
+		task.CreatedAt = 1234567890 + int64(i)
+		task.UpdatedAt = task.CreatedAt
+		mockDB.taskRepo.Create(context.Background(), task)
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(mockDB, logger)
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantIDs    []uuid.UUID
+	}{
+		{"", http.StatusOK, []uuid.UUID{describedID, bareID}},
+		{"?has_description=true", http.StatusOK, []uuid.UUID{describedID}},
+		{"?has_description=false", http.StatusOK, []uuid.UUID{bareID}},
+		{"?has_description=maybe", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			h.GetAll(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response tasks.ListTasksResponse
+			if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			got := make([]uuid.UUID, len(response.Tasks))
+			for i, task := range response.Tasks {
+				got[i] = uuid.MustParse(task.Id)
+			}
+			if !slices.Equal(got, tt.wantIDs) {
+				t.Errorf("expected %v, got %v", tt.wantIDs, got)
+			}
+		})
+	}
+}
+
+// TestIntegrationEventsPublished tests that successful writes publish task events
+func TestIntegrationEventsPublished(t *testing.T) {
+	publisher := &RecordingPublisher{}
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(NewMockDatabase(), logger, WithEventPublisher(publisher))
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/tasks", h.Create)
+	router.Put("/api/v1/tasks/{id}", h.Update)
+	router.Delete("/api/v1/tasks/{id}", h.Delete)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewReader([]byte(`{"title":"Evented"}`)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var created tasks.GetTaskResponse
+	if err := protojson.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	taskID := created.Task.Id
+
+	// A failed write must not publish anything
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/tasks/"+taskID, bytes.NewReader([]byte(`{"title":""}`)))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/tasks/"+taskID, bytes.NewReader([]byte(`{"title":"Renamed"}`)))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/tasks/"+taskID, nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	got := publisher.Events()
+	want := []events.EventType{events.TaskCreated, events.TaskUpdated, events.TaskDeleted}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(got))
+	}
+
+	for i, event := range got {
+		if event.Type != want[i] || event.TaskID.String() != taskID {
+			t.Errorf("event %d: expected %s for %s, got %s for %s", i, want[i], taskID, event.Type, event.TaskID)
+		}
+	}
+
+	if got[1].Task == nil || got[1].Task.Title != "Renamed" {
+		t.Error("expected update event to carry the updated task")
+	}
+	wantChanges := []events.FieldChange{{Field: "title", From: "Evented", To: "Renamed"}}
+	if !reflect.DeepEqual(got[1].Changes, wantChanges) {
+		t.Errorf("expected update event changes %v, got %v", wantChanges, got[1].Changes)
+	}
+
+	if got[2].Task != nil {
+		t.Error("expected delete event without task state")
+	}
+}
+
+// TestIntegrationTrailingSlash tests that every route resolves with and without a trailing slash
+func TestIntegrationTrailingSlash(t *testing.T) {
+	router, h := setupRouter()
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440010")
+	taskID := taskUUID.String()
+
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:        taskUUID,
+		Title:     "Slash Task",
+		CreatedAt: 1234567890,
+		UpdatedAt: 1234567890,
+	})
+
+	tests := []struct {
+		method      string
+		path        string
+		body        string
+		contentType string
+		wantStatus  int
+	}{
+		{http.MethodGet, "/api/v1/tasks", "", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/tasks", `{"title":"Created"}`, "", http.StatusCreated},
+		{http.MethodPost, "/api/v1/tasks/lookup", `{"ids":["` + taskID + `"]}`, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/tasks/" + taskID, "", "", http.StatusOK},
+		{http.MethodPut, "/api/v1/tasks/" + taskID, `{"title":"Updated"}`, "", http.StatusOK},
+		{http.MethodPatch, "/api/v1/tasks/" + taskID, `[]`, jsonPatchContentType, http.StatusOK},
+		{http.MethodPost, "/api/v1/tasks/" + taskID + "/assign", `{"assigneeId":"alice"}`, "", http.StatusOK},
+		{http.MethodPost, "/api/v1/tasks/" + taskID + "/unassign", "", "", http.StatusOK},
+		{http.MethodDelete, "/api/v1/tasks/" + taskID, "", "", http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		for _, path := range []string{tt.path, tt.path + "/"} {
+			t.Run(tt.method+" "+path, func(t *testing.T) {
+				req := httptest.NewRequest(tt.method, path, bytes.NewReader([]byte(tt.body)))
+				if tt.contentType != "" {
+					req.Header.Set("Content-Type", tt.contentType)
+				}
+				w := httptest.NewRecorder()
+
+				router.ServeHTTP(w, req)
+
+				if w.Code != tt.wantStatus {
+					t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+				}
+			})
+		}
+	}
+}
+
+// TestIntegrationClock tests that timestamps and events come from the injected clock
+func TestIntegrationClock(t *testing.T) {
+	start := time.Date(2025, 11, 13, 10, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	publisher := &RecordingPublisher{}
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(NewMockDatabase(), logger, WithClock(clock), WithEventPublisher(publisher))
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/tasks", h.Create)
+	router.Put("/api/v1/tasks/{id}", h.Update)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewReader([]byte(`{"title":"Clocked"}`)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var created tasks.GetTaskResponse
+	if err := protojson.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if got := created.Task.CreatedAt.AsTime(); !got.Equal(start) {
+		t.Errorf("expected createdAt %s, got %s", start, got)
+	}
+
+	clock.Advance(time.Hour)
+
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/tasks/"+created.Task.Id, bytes.NewReader([]byte(`{"title":"Reclocked"}`)))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var updated tasks.GetTaskResponse
+	if err := protojson.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if got := updated.Task.CreatedAt.AsTime(); !got.Equal(start) {
+		t.Errorf("expected createdAt to stay %s, got %s", start, got)
+	}
+
+	if got := updated.Task.UpdatedAt.AsTime(); !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected updatedAt %s, got %s", start.Add(time.Hour), got)
+	}
+
+	got := publisher.Events()
+	if len(got) != 2 || !got[0].OccurredAt.Equal(start) || !got[1].OccurredAt.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected events at %s and %s, got %+v", start, start.Add(time.Hour), got)
+	}
+}
+
+// TestIntegrationExport tests NDJSON export, including filters and format validation
+func TestIntegrationExport(t *testing.T) {
+	router, h := setupRouter()
+
+	for i, completed := range []bool{false, true, false} {
+		h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+			ID:        uuid.MustParse(fmt.Sprintf("550e8400-e29b-41d4-a716-4466554400%d", 11+i)),
+			Title:     fmt.Sprintf("Export %d", i),
+			Completed: completed,
+			CreatedAt: 1234567890,
+			UpdatedAt: 1234567890,
+		})
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCode  int
+		wantLines int
+	}{
+		{"all tasks", "", http.StatusOK, 3},
+		{"explicit format", "?format=ndjson", http.StatusOK, 3},
+		{"filtered", "?completed=false", http.StatusOK, 2},
+		{"no matches", "?assignee=nobody", http.StatusOK, 0},
+		{"unknown format", "?format=csv", http.StatusBadRequest, 0},
+		{"invalid filter", "?completed=maybe", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/export"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+				t.Errorf("expected Content-Type application/x-ndjson, got %s", ct)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(w.Body.String(), "\n"), "\n")
+			if w.Body.Len() == 0 {
+				lines = nil
+			}
+
+			if len(lines) != tt.wantLines {
+				t.Fatalf("expected %d lines, got %d: %q", tt.wantLines, len(lines), w.Body.String())
+			}
+
+			for _, line := range lines {
+				var task tasks.Task
+				if err := protojson.Unmarshal([]byte(line), &task); err != nil {
+					t.Errorf("line %q is not a task: %v", line, err)
+				}
+			}
+		})
+	}
+}
+
+// TestIntegrationArchive tests archiving, the default list exclusion and the archived filter
+func TestIntegrationArchive(t *testing.T) {
+	router, h := setupRouter()
+
+	activeID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440014")
+	archivedID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440015")
+	for _, id := range []uuid.UUID{activeID, archivedID} {
+		h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+			ID:        id,
+			Title:     "Archivable",
+			CreatedAt: 1234567890,
+			UpdatedAt: 1234567890,
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/"+archivedID.String()+"/archive", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response tasks.GetTaskResponse
+	if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if !response.Task.Archived {
+		t.Error("expected archived task in response")
+	}
+
+	stored, _ := h.db.GetTaskRepository().FindByID(context.Background(), archivedID)
+	if !stored.Archived || stored.UpdatedAt == 1234567890 {
+		t.Errorf("expected stored task archived with a new updatedAt, got %+v", stored)
+	}
+
+	listTests := []struct {
+		query   string
+		wantIDs []uuid.UUID
+	}{
+		{"", []uuid.UUID{activeID}},
+		{"?archived=false", []uuid.UUID{activeID}},
+		{"?archived=true", []uuid.UUID{archivedID}},
+		{"?archived=all", []uuid.UUID{activeID, archivedID}},
+	}
+
+	for _, tt := range listTests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks"+tt.query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var list tasks.ListTasksResponse
+		if err := protojson.Unmarshal(w.Body.Bytes(), &list); err != nil {
+			t.Fatalf("%q: failed to unmarshal response: %v", tt.query, err)
+		}
+
+		if len(list.Tasks) != len(tt.wantIDs) {
+			t.Errorf("%q: expected %d tasks, got %d", tt.query, len(tt.wantIDs), len(list.Tasks))
+			continue
+		}
+		for _, id := range tt.wantIDs {
+			if !slices.ContainsFunc(list.Tasks, func(task *tasks.Task) bool { return task.Id == id.String() }) {
+				t.Errorf("%q: expected task %s in list", tt.query, id)
+			}
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/tasks?archived=maybe", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid archived filter, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/"+archivedID.String()+"/unarchive", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	stored, _ = h.db.GetTaskRepository().FindByID(context.Background(), archivedID)
+	if w.Code != http.StatusOK || stored.Archived {
+		t.Errorf("expected unarchive to succeed, got status %d and archived=%v", w.Code, stored.Archived)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/"+uuid.New().String()+"/archive", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for missing task, got %d", w.Code)
+	}
+}
+
+// TestIntegrationErrorCodes tests that failures carry a specific code alongside the broad type
+func TestIntegrationErrorCodes(t *testing.T) {
+	router, h := setupRouter()
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440016")
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:        taskUUID,
+		Title:     "Coded",
+		CreatedAt: 1234567890,
+		UpdatedAt: 1234567890,
+	})
+	taskPath := "/api/v1/tasks/" + taskUUID.String()
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantType    errors.ErrorType
+		wantCode    errors.ErrorCode
+	}{
+		{"invalid id", http.MethodGet, "/api/v1/tasks/not-a-uuid", "", "", http.StatusBadRequest, errors.ErrorTypeBadRequest, errors.CodeInvalidTaskID},
+		{"missing task", http.MethodGet, "/api/v1/tasks/" + uuid.New().String(), "", "", http.StatusNotFound, errors.ErrorTypeNotFound, errors.CodeTaskNotFound},
+		{"malformed json", http.MethodPost, "/api/v1/tasks", "", `{"title":`, http.StatusBadRequest, errors.ErrorTypeBadRequest, errors.CodeInvalidJSON},
+		{"wrong value type", http.MethodPost, "/api/v1/tasks", "", `{"title":5}`, http.StatusBadRequest, errors.ErrorTypeBadRequest, errors.CodeInvalidJSON},
+		{"title too long", http.MethodPost, "/api/v1/tasks", "", `{"title":"` + strings.Repeat("x", 101) + `"}`, http.StatusUnprocessableEntity, errors.ErrorTypeValidation, errors.CodeTitleTooLong},
+		{"invalid assignee", http.MethodPost, taskPath + "/assign", "", `{"assigneeId":"a b"}`, http.StatusUnprocessableEntity, errors.ErrorTypeValidation, errors.CodeAssigneeInvalid},
+		{"invalid lookup ids", http.MethodPost, "/api/v1/tasks/lookup", "", `{"ids":[]}`, http.StatusUnprocessableEntity, errors.ErrorTypeValidation, errors.CodeLookupIDsInvalid},
+		{"invalid filter", http.MethodGet, "/api/v1/tasks?completed=maybe", "", "", http.StatusBadRequest, errors.ErrorTypeBadRequest, errors.CodeInvalidQuery},
+		{"patch without patch type", http.MethodPatch, taskPath, "application/json", `[]`, http.StatusUnsupportedMediaType, errors.ErrorTypeMediaType, errors.CodeUnsupportedContentType},
+		{"invalid patch", http.MethodPatch, taskPath, jsonPatchContentType, `[{"op":"replace","path":"/id","value":"x"}]`, http.StatusBadRequest, errors.ErrorTypeBadRequest, errors.CodeInvalidPatch},
+		{"failed patch test", http.MethodPatch, taskPath, jsonPatchContentType, `[{"op":"test","path":"/title","value":"Other"}]`, http.StatusConflict, errors.ErrorTypeConflict, errors.CodeVersionConflict},
+		{"unknown route", http.MethodGet, "/api/v1/unknown", "", "", http.StatusNotFound, errors.ErrorTypeNotFound, errors.CodeRouteNotFound},
+		{"method not allowed", http.MethodPost, taskPath, "", "", http.StatusMethodNotAllowed, errors.ErrorTypeMethod, errors.CodeMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			var response errors.APIError
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if response.Type != tt.wantType || response.Code != tt.wantCode {
+				t.Errorf("expected %s/%s, got %s/%s", tt.wantType, tt.wantCode, response.Type, response.Code)
+			}
+		})
+	}
+}
+
+// TestIntegrationTimestampFormat tests that timestamps are returned as RFC 3339 strings, not unix integers
+func TestIntegrationTimestampFormat(t *testing.T) {
+	router, h := setupRouter()
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440017")
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:        taskUUID,
+		Title:     "Timestamped",
+		CreatedAt: 1763028000,
+		UpdatedAt: 1763031600,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/"+taskUUID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response struct {
+		Task map[string]any `json:"task"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	want := map[string]string{
+		"createdAt": "2025-11-13T10:00:00Z",
+		"updatedAt": "2025-11-13T11:00:00Z",
+	}
+	for field, value := range want {
+		if response.Task[field] != value {
+			t.Errorf("expected %s %q, got %v", field, value, response.Task[field])
+		}
+	}
+}
+
+// TestIntegrationEmptyBody tests that empty and whitespace-only bodies are rejected before parsing
+func TestIntegrationEmptyBody(t *testing.T) {
+	router, h := setupRouter()
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440018")
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:        taskUUID,
+		Title:     "Unchanged",
+		CreatedAt: 1234567890,
+		UpdatedAt: 1234567890,
+	})
+	taskPath := "/api/v1/tasks/" + taskUUID.String()
+
+	requests := []struct {
+		method      string
+		path        string
+		contentType string
+	}{
+		{http.MethodPost, "/api/v1/tasks", "application/json"},
+		{http.MethodPut, taskPath, "application/json"},
+		{http.MethodPatch, taskPath, jsonPatchContentType},
+	}
+
+	for _, rq := range requests {
+		for _, body := range []string{"", " \n\t "} {
+			t.Run(fmt.Sprintf("%s %q", rq.method, body), func(t *testing.T) {
+				req := httptest.NewRequest(rq.method, rq.path, strings.NewReader(body))
+				req.Header.Set("Content-Type", rq.contentType)
+				w := httptest.NewRecorder()
+
+				router.ServeHTTP(w, req)
+
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("expected status 400, got %d", w.Code)
+				}
+
+				var response errors.APIError
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+
+				if response.Code != errors.CodeBodyRequired || response.Message != "Request body is required" {
+					t.Errorf("expected %s \"Request body is required\", got %s %q", errors.CodeBodyRequired, response.Code, response.Message)
+				}
+			})
+		}
+	}
+
+	stored, _ := h.db.GetTaskRepository().FindByID(context.Background(), taskUUID)
+	if stored.Title != "Unchanged" || stored.UpdatedAt != 1234567890 {
+		t.Errorf("expected the task to be untouched, got %+v", stored)
+	}
+}
+
+// TestIntegrationCompleteReopen tests the completion shortcuts and completedAt bookkeeping
+func TestIntegrationCompleteReopen(t *testing.T) {
+	start := time.Date(2025, 11, 13, 10, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(NewMockDatabase(), logger, WithClock(clock))
+
+	router := chi.NewRouter()
+	router.Put("/api/v1/tasks/{id}", h.Update)
+	router.Post("/api/v1/tasks/{id}/complete", h.Complete)
+	router.Post("/api/v1/tasks/{id}/reopen", h.Reopen)
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440019")
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:          taskUUID,
+		Title:       "Finish me",
+		Description: "Kept as is",
+		CreatedAt:   1234567890,
+		UpdatedAt:   1234567890,
+	})
+	taskPath := "/api/v1/tasks/" + taskUUID.String()
+
+	post := func(path string) (int, *tasks.Task) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response tasks.GetTaskResponse
+		if w.Code == http.StatusOK {
+			if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+		}
+		return w.Code, response.Task
+	}
+
+	code, task := post(taskPath + "/complete")
+	if code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", code)
+	}
+	if !task.Completed || !task.CompletedAt.AsTime().Equal(start) {
+		t.Errorf("expected task completed at %s, got completed=%v completedAt=%v", start, task.Completed, task.CompletedAt)
+	}
+	if task.Description != "Kept as is" {
+		t.Errorf("expected description to be untouched, got %q", task.Description)
+	}
+
+	// Completing again keeps the original completion time
+	clock.Advance(time.Hour)
+	_, task = post(taskPath + "/complete")
+	if !task.CompletedAt.AsTime().Equal(start) {
+		t.Errorf("expected completedAt to stay %s, got %s", start, task.CompletedAt.AsTime())
+	}
+
+	code, task = post(taskPath + "/reopen")
+	if code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", code)
+	}
+	if task.Completed || task.CompletedAt != nil {
+		t.Errorf("expected reopened task without completedAt, got completed=%v completedAt=%v", task.Completed, task.CompletedAt)
+	}
+
+	stored, _ := h.db.GetTaskRepository().FindByID(context.Background(), taskUUID)
+	if stored.Completed || stored.CompletedAt != nil || stored.UpdatedAt != start.Add(time.Hour).Unix() {
+		t.Errorf("expected stored task reopened with a new updatedAt, got %+v", stored)
+	}
+
+	// Full updates keep completedAt in step too
+	req := httptest.NewRequest(http.MethodPut, taskPath, bytes.NewReader([]byte(`{"title":"Finish me","completed":true}`)))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	stored, _ = h.db.GetTaskRepository().FindByID(context.Background(), taskUUID)
+	if stored.CompletedAt == nil || *stored.CompletedAt != start.Add(time.Hour).Unix() {
+		t.Errorf("expected update to set completedAt, got %v", stored.CompletedAt)
+	}
+
+	if code, _ := post("/api/v1/tasks/" + uuid.New().String() + "/complete"); code != http.StatusNotFound {
+		t.Errorf("expected status 404 for missing task, got %d", code)
+	}
+	if code, _ := post("/api/v1/tasks/not-a-uuid/reopen"); code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid ID, got %d", code)
+	}
+}
+
+// TestIntegrationMaxResults tests that a capped list is flagged as partial and a complete one is not
+func TestIntegrationMaxResults(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(NewMockDatabase(), logger, WithMaxResults(3))
+
+	router := chi.NewRouter()
+	router.Get("/api/v1/tasks", h.GetAll)
+
+	for i := range 4 {
+		h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+			ID:        uuid.New(),
+			Title:     fmt.Sprintf("Task %d", i),
+			Completed: i == 0,
+			CreatedAt: 1234567890,
+			UpdatedAt: 1234567890,
+		})
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		wantStatus    int
+		wantCount     int
+		wantTruncated bool
+	}{
+		{"more matches than the cap", "", http.StatusPartialContent, 3, true},
+		{"exactly the cap", "?completed=false", http.StatusOK, 3, false},
+		{"fewer than the cap", "?completed=true", http.StatusOK, 1, false},
+		{"client limit below the cap", "?limit=2", http.StatusOK, 2, false},
+		{"client limit above the cap", "?limit=50", http.StatusPartialContent, 3, true},
+		{"offset", "?offset=2", http.StatusOK, 2, false},
+		{"offset beyond the end", "?offset=10", http.StatusOK, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			var list tasks.ListTasksResponse
+			if err := protojson.Unmarshal(w.Body.Bytes(), &list); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if len(list.Tasks) != tt.wantCount {
+				t.Errorf("expected %d tasks, got %d", tt.wantCount, len(list.Tasks))
+			}
+
+			truncated := w.Header().Get("X-Result-Truncated") == "true"
+			if truncated != tt.wantTruncated {
+				t.Errorf("expected truncated=%v, got headers %v", tt.wantTruncated, w.Header())
+			}
+			if tt.wantTruncated && w.Header().Get("X-Result-Limit") != "3" {
+				t.Errorf("expected X-Result-Limit 3, got %q", w.Header().Get("X-Result-Limit"))
+			}
+		})
+	}
+}
+
+// TestIntegrationPagingBoundary tests pages at and past the end of the list,
+// and that a page never exceeds the limit even if the repository returns more
+func TestIntegrationPagingBoundary(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+
+	tests := []struct {
+		name       string
+		extra      int
+		maxResults int
+		query      string
+		wantStatus int
+		wantTitles []string
+	}{
+		{"last task", 0, 0, "?offset=2", http.StatusOK, []string{"Task 2"}},
+		{"offset at the end", 0, 0, "?offset=3", http.StatusOK, nil},
+		{"offset past the end", 0, 0, "?offset=4", http.StatusOK, nil},
+		{"limit past the end", 0, 0, "?offset=2&limit=5", http.StatusOK, []string{"Task 2"}},
+		{"repository returns extra tasks", 2, 0, "?limit=1", http.StatusOK, []string{"Task 0"}},
+		{"repository returns extra tasks past the cap", 2, 2, "", http.StatusPartialContent, []string{"Task 0", "Task 1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := NewMockDatabase()
+			db.taskRepo.extra = tt.extra
+			for i := range 3 {
+				db.GetTaskRepository().Create(context.Background(), &database.Task{
+					ID:        uuid.New(),
+					Title:     fmt.Sprintf("Task %d", i),
+					CreatedAt: 1234567890 + int64(i),
+					UpdatedAt: 1234567890 + int64(i),
+				})
+			}
+			h := NewTaskHandler(db, logger, WithMaxResults(tt.maxResults))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks"+tt.query, nil)
+			w := httptest.NewRecorder()
+			h.GetAll(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			var list tasks.ListTasksResponse
+			if err := protojson.Unmarshal(w.Body.Bytes(), &list); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			var titles []string
+			for _, task := range list.Tasks {
+				titles = append(titles, task.Title)
+			}
+			if !slices.Equal(titles, tt.wantTitles) {
+				t.Errorf("expected %v, got %v", tt.wantTitles, titles)
+			}
+		})
+	}
+}
+
+// TestIntegrationListSort tests that the configured default sort applies unless ?sort= overrides it
+func TestIntegrationListSort(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	db := NewMockDatabase()
+	for i, title := range []string{"Bravo", "Charlie", "Alpha"} {
+		db.GetTaskRepository().Create(context.Background(), &database.Task{
+			ID:        uuid.New(),
+			Title:     title,
+			CreatedAt: 1234567890 + int64(i),
+			UpdatedAt: 1234567890,
+		})
+	}
+	newestFirst := []database.SortKey{{Field: "createdAt", Descending: true}}
+
+	tests := []struct {
+		name        string
+		defaultSort []database.SortKey
+		query       string
+		wantStatus  int
+		want        []string
+	}{
+		{"repository default", nil, "", http.StatusOK, []string{"Bravo", "Charlie", "Alpha"}},
+		{"configured default", newestFirst, "", http.StatusOK, []string{"Alpha", "Charlie", "Bravo"}},
+		{"explicit sort", newestFirst, "?sort=title", http.StatusOK, []string{"Alpha", "Bravo", "Charlie"}},
+		{"explicit descending", nil, "?sort=-title", http.StatusOK, []string{"Charlie", "Bravo", "Alpha"}},
+		{"unknown field", newestFirst, "?sort=priority", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskHandler(db, logger, WithDefaultSort(tt.defaultSort))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks"+tt.query, nil)
+			w := httptest.NewRecorder()
+			h.GetAll(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.want == nil {
+				return
+			}
+
+			var list tasks.ListTasksResponse
+			if err := protojson.Unmarshal(w.Body.Bytes(), &list); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			got := make([]string, len(list.Tasks))
+			for i, task := range list.Tasks {
+				got[i] = task.Title
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected order %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestIntegrationMaxResponseBytes tests that a list is cut to the byte ceiling with the offset to continue from
+func TestIntegrationMaxResponseBytes(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	db := NewMockDatabase()
+	for i := range 4 {
+		db.GetTaskRepository().Create(context.Background(), &database.Task{
+			ID:          uuid.New(),
+			Title:       fmt.Sprintf("Task %d", i),
+			Description: strings.Repeat("x", 300),
+			CreatedAt:   1234567890 + int64(i),
+			UpdatedAt:   1234567890,
+		})
+	}
+
+	tests := []struct {
+		name         string
+		maxBytes     int
+		query        string
+		wantStatus   int
+		wantCount    int
+		wantNextPage string
+	}{
+		{"two of four fit", 1000, "", http.StatusPartialContent, 2, "2"},
+		{"the rest fits", 1000, "?offset=2", http.StatusOK, 2, ""},
+		{"client limit fits", 1000, "?limit=1", http.StatusOK, 1, ""},
+		{"first task is always returned", 10, "?offset=1", http.StatusPartialContent, 1, "2"},
+		{"disabled", 0, "", http.StatusOK, 4, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskHandler(db, logger, WithMaxResponseBytes(tt.maxBytes))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks"+tt.query, nil)
+			w := httptest.NewRecorder()
+			h.GetAll(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			var list tasks.ListTasksResponse
+			if err := protojson.Unmarshal(w.Body.Bytes(), &list); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if len(list.Tasks) != tt.wantCount {
+				t.Errorf("expected %d tasks, got %d", tt.wantCount, len(list.Tasks))
+			}
+
+			if got := w.Header().Get("X-Next-Offset"); got != tt.wantNextPage {
+				t.Errorf("expected X-Next-Offset %q, got %q", tt.wantNextPage, got)
+			}
+			truncated := w.Header().Get("X-Result-Truncated") == "true"
+			if truncated != (tt.wantNextPage != "") {
+				t.Errorf("expected truncated=%v, got headers %v", tt.wantNextPage != "", w.Header())
+			}
+		})
+	}
+}
+
+// TestIntegrationHead tests that HEAD mirrors GET's status and headers without a body
+func TestIntegrationHead(t *testing.T) {
+	router, h := setupRouter()
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440020")
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:        taskUUID,
+		Title:     "Headed",
+		CreatedAt: 1234567890,
+		UpdatedAt: 1234567890,
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"existing task", "/api/v1/tasks/" + taskUUID.String(), http.StatusOK},
+		{"missing task", "/api/v1/tasks/" + uuid.New().String(), http.StatusNotFound},
+		{"invalid ID", "/api/v1/tasks/not-a-uuid", http.StatusBadRequest},
+		{"collection", "/api/v1/tasks", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			get := httptest.NewRecorder()
+			router.ServeHTTP(get, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			head := httptest.NewRecorder()
+			router.ServeHTTP(head, httptest.NewRequest(http.MethodHead, tt.path, nil))
+
+			if head.Code != tt.wantStatus || get.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got GET %d and HEAD %d", tt.wantStatus, get.Code, head.Code)
+			}
+			if head.Body.Len() != 0 {
+				t.Errorf("expected no body, got %q", head.Body.String())
+			}
+			if got, want := head.Header().Get("Content-Type"), get.Header().Get("Content-Type"); got != want {
+				t.Errorf("expected Content-Type %q, got %q", want, got)
+			}
+			if got, want := head.Header().Get("Content-Length"), strconv.Itoa(get.Body.Len()); got != want {
+				t.Errorf("expected Content-Length %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+// TestIntegrationPatchBatch tests best-effort and atomic batch patches
+func TestIntegrationPatchBatch(t *testing.T) {
+	firstID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440021")
+	secondID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440022")
+	missingID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440023")
+
+	type itemResult struct {
+		Index  int              `json:"index"`
+		ID     string           `json:"id"`
+		Status int              `json:"status"`
+		Task   json.RawMessage  `json:"task"`
+		Error  *errors.APIError `json:"error"`
+	}
+
+	patch := func(t *testing.T, body string) (*httptest.ResponseRecorder, []itemResult, *TaskHandler) {
+		t.Helper()
+		router, h := setupRouter()
+		for _, id := range []uuid.UUID{firstID, secondID} {
+			h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+				ID:          id,
+				Title:       "Original",
+				Description: "Untouched",
+				CreatedAt:   1234567890,
+				UpdatedAt:   1234567890,
+			})
+		}
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/tasks/batch", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Results []itemResult `json:"results"`
+		}
+		if w.Code == http.StatusOK || w.Code == http.StatusMultiStatus {
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+		}
+		return w, response.Results, h
+	}
+
+	t.Run("all succeed", func(t *testing.T) {
+		w, results, h := patch(t, `{"updates":[
+			{"id":"`+firstID.String()+`","completed":true},
+			{"id":"`+secondID.String()+`","title":"Renamed"}]}`)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		for _, res := range results {
+			if res.Status != http.StatusOK || res.Task == nil {
+				t.Errorf("expected item %d applied, got %+v", res.Index, res)
+			}
+		}
+
+		first, _ := h.db.GetTaskRepository().FindByID(context.Background(), firstID)
+		if !first.Completed || first.CompletedAt == nil || first.Title != "Original" || first.Description != "Untouched" {
+			t.Errorf("expected only completed to change, got %+v", first)
+		}
+		second, _ := h.db.GetTaskRepository().FindByID(context.Background(), secondID)
+		if second.Title != "Renamed" || second.Completed || second.Description != "Untouched" {
+			t.Errorf("expected only the title to change, got %+v", second)
+		}
+	})
+
+	body := `{"updates":[
+		{"id":"` + firstID.String() + `","title":"Applied"},
+		{"id":"` + missingID.String() + `","title":"Nowhere"},
+		{"id":"` + secondID.String() + `","title":""},
+		{"id":"not-a-uuid","title":"Bad"},
+		{"id":"` + firstID.String() + `","title":"Again"}]%s}`
+
+	t.Run("mixed best effort", func(t *testing.T) {
+		w, results, h := patch(t, fmt.Sprintf(body, ""))
+
+		if w.Code != http.StatusMultiStatus {
+			t.Fatalf("expected status 207, got %d: %s", w.Code, w.Body.String())
+		}
+
+		want := []struct {
+			status int
+			code   errors.ErrorCode
+		}{
+			{http.StatusOK, ""},
+			{http.StatusNotFound, errors.CodeTaskNotFound},
+			{http.StatusUnprocessableEntity, errors.CodeTitleRequired},
+			{http.StatusUnprocessableEntity, errors.CodeInvalidTaskID},
+			{http.StatusUnprocessableEntity, errors.CodeBatchDuplicateID},
+		}
+		if len(results) != len(want) {
+			t.Fatalf("expected %d results, got %d", len(want), len(results))
+		}
+		for i, res := range results {
+			if res.Index != i || res.Status != want[i].status {
+				t.Errorf("item %d: expected status %d, got %+v", i, want[i].status, res)
+			}
+			if want[i].code != "" && (res.Error == nil || res.Error.Code != want[i].code) {
+				t.Errorf("item %d: expected code %s, got %+v", i, want[i].code, res.Error)
+			}
+		}
+
+		first, _ := h.db.GetTaskRepository().FindByID(context.Background(), firstID)
+		if first.Title != "Applied" {
+			t.Errorf("expected the valid item to be applied, got title %q", first.Title)
+		}
+	})
+
+	t.Run("atomic with a failure", func(t *testing.T) {
+		w, results, h := patch(t, fmt.Sprintf(body, `,"atomic":true`))
+
+		if w.Code != http.StatusMultiStatus {
+			t.Fatalf("expected status 207, got %d: %s", w.Code, w.Body.String())
+		}
+		if results[0].Status != http.StatusFailedDependency || results[0].Error.Code != errors.CodeBatchNotApplied {
+			t.Errorf("expected the valid item to be skipped, got %+v", results[0])
+		}
+		if results[1].Status != http.StatusNotFound {
+			t.Errorf("expected the missing task to report 404, got %+v", results[1])
+		}
+
+		first, _ := h.db.GetTaskRepository().FindByID(context.Background(), firstID)
+		if first.Title != "Original" || first.UpdatedAt != 1234567890 {
+			t.Errorf("expected no writes, got %+v", first)
+		}
+	})
+
+	t.Run("atomic success", func(t *testing.T) {
+		w, _, h := patch(t, `{"atomic":true,"updates":[
+			{"id":"`+firstID.String()+`","title":"One"},
+			{"id":"`+secondID.String()+`","title":"Two"}]}`)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		first, _ := h.db.GetTaskRepository().FindByID(context.Background(), firstID)
+		second, _ := h.db.GetTaskRepository().FindByID(context.Background(), secondID)
+		if first.Title != "One" || second.Title != "Two" {
+			t.Errorf("expected both updates applied, got %q and %q", first.Title, second.Title)
+		}
+	})
+
+	t.Run("batch size", func(t *testing.T) {
+		items := make([]string, 101)
+		for i := range items {
+			items[i] = `{"id":"` + uuid.New().String() + `"}`
+		}
+
+		for _, body := range []string{`{"updates":[]}`, `{"updates":[` + strings.Join(items, ",") + `]}`} {
+			w, _, _ := patch(t, body)
+			if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), string(errors.CodeBatchInvalid)) {
+				t.Errorf("expected 422 %s, got %d: %s", errors.CodeBatchInvalid, w.Code, w.Body.String())
+			}
+		}
+	})
+}
+
+// TestIntegrationPatchBatchRace tests that a batch patch does not overwrite a
+// task written between its read and its write
+func TestIntegrationPatchBatchRace(t *testing.T) {
+	racedID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440054")
+	otherID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440055")
+
+	tests := []struct {
+		name       string
+		atomic     bool
+		wantOther  int
+		otherTitle string
+	}{
+		{"best effort", false, http.StatusOK, "Batch"},
+		{"atomic", true, http.StatusFailedDependency, "Original"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := NewMockDatabase()
+			repo := &interleavingRepository{MockTaskRepository: mockDB.taskRepo}
+			clock := NewFakeClock(time.Unix(1700000000, 0))
+			logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+				Level: slog.LevelError,
+			}))
+			h := NewTaskHandler(&interleavingDatabase{MockDatabase: mockDB, repo: repo}, logger, WithClock(clock))
+
+			for _, id := range []uuid.UUID{racedID, otherID} {
+				mockDB.taskRepo.Create(context.Background(), &database.Task{
+					ID:          id,
+					Title:       "Original",
+					Description: "Original Description",
+					CreatedAt:   1699999000,
+					UpdatedAt:   1699999000,
+				})
+			}
+
+			concurrent := "Concurrent Writer"
+			repo.between = func() {
+				clock.Advance(time.Second)
+				mockDB.taskRepo.FindOneAndUpdate(context.Background(), racedID, database.TaskUpdate{
+					Description: &concurrent,
+					UpdatedAt:   clock.Now().Unix(),
+				})
+			}
+
+			body := fmt.Sprintf(`{"atomic":%t,"updates":[
+				{"id":"%s","title":"Batch"},
+				{"id":"%s","title":"Batch"}]}`, tt.atomic, racedID, otherID)
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/tasks/batch", strings.NewReader(body))
+			w := httptest.NewRecorder()
+			h.PatchBatch(w, req)
+
+			if w.Code != http.StatusMultiStatus {
+				t.Fatalf("expected status 207, got %d: %s", w.Code, w.Body.String())
+			}
+			var response batchResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			raced, other := response.Results[0], response.Results[1]
+			if raced.Status != http.StatusConflict || raced.Error == nil || raced.Error.Code != errors.CodeVersionConflict {
+				t.Errorf("expected the raced task to report 409 %s, got %+v", errors.CodeVersionConflict, raced)
+			}
+			if other.Status != tt.wantOther {
+				t.Errorf("expected the other task to report %d, got %+v", tt.wantOther, other)
+			}
+
+			stored, _ := mockDB.taskRepo.FindByID(context.Background(), racedID)
+			if stored.Title != "Original" || stored.Description != concurrent {
+				t.Errorf("expected only the concurrent write on the raced task, got %+v", stored)
+			}
+			stored, _ = mockDB.taskRepo.FindByID(context.Background(), otherID)
+			if stored.Title != tt.otherTitle {
+				t.Errorf("expected the other task titled %q, got %q", tt.otherTitle, stored.Title)
+			}
+		})
+	}
+}
+
+// TestIntegrationCreateBatch tests batch creates with valid, invalid and mixed items
+func TestIntegrationCreateBatch(t *testing.T) {
+	type itemResult struct {
+		Index  int              `json:"index"`
+		ID     string           `json:"id"`
+		Status int              `json:"status"`
+		Task   json.RawMessage  `json:"task"`
+		Error  *errors.APIError `json:"error"`
+	}
+
+	create := func(t *testing.T, body string) (int, []itemResult, int) {
+		t.Helper()
+		router, h := setupRouter()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/batch", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Results []itemResult `json:"results"`
+		}
+		if w.Code == http.StatusCreated || w.Code == http.StatusMultiStatus {
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+		}
+
+		stored, _ := h.db.GetTaskRepository().FindAll(context.Background(), database.TaskQuery{})
+		return w.Code, response.Results, len(stored)
+	}
+
+	mixed := `{"tasks":[{"title":"First"},{"title":""},{"title":"Third","description":"Kept"}]%s}`
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantItems  []int
+		wantStored int
+	}{
+		{"all valid", `{"tasks":[{"title":"One"},{"title":"Two"}]}`, http.StatusCreated,
+			[]int{http.StatusCreated, http.StatusCreated}, 2},
+		{"all invalid", `{"tasks":[{"title":""},{"title":"Bad\u0000title"}]}`, http.StatusMultiStatus,
+			[]int{http.StatusUnprocessableEntity, http.StatusUnprocessableEntity}, 0},
+		{"mixed best effort", fmt.Sprintf(mixed, ""), http.StatusMultiStatus,
+			[]int{http.StatusCreated, http.StatusUnprocessableEntity, http.StatusCreated}, 2},
+		{"mixed atomic", fmt.Sprintf(mixed, `,"atomic":true`), http.StatusMultiStatus,
+			[]int{http.StatusFailedDependency, http.StatusUnprocessableEntity, http.StatusFailedDependency}, 0},
+		{"all valid atomic", `{"atomic":true,"tasks":[{"title":"One"},{"title":"Two"}]}`, http.StatusCreated,
+			[]int{http.StatusCreated, http.StatusCreated}, 2},
+		{"empty batch", `{"tasks":[]}`, http.StatusUnprocessableEntity, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, results, stored := create(t, tt.body)
+
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if len(results) != len(tt.wantItems) {
+				t.Fatalf("expected %d results, got %d", len(tt.wantItems), len(results))
+			}
+			for i, res := range results {
+				if res.Index != i || res.Status != tt.wantItems[i] {
+					t.Errorf("item %d: expected status %d, got %+v", i, tt.wantItems[i], res)
+				}
+				if created := res.Status == http.StatusCreated; created != (res.Task != nil && res.ID != "") {
+					t.Errorf("item %d: expected a task and ID exactly when created, got %+v", i, res)
+				}
+			}
+			if stored != tt.wantStored {
+				t.Errorf("expected %d stored tasks, got %d", tt.wantStored, stored)
+			}
+		})
+	}
+}
+
+// TestIntegrationNextPrev tests stepping through tasks in createdAt order
+func TestIntegrationNextPrev(t *testing.T) {
+	router, h := setupRouter()
+
+	ids := []uuid.UUID{
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440024"),
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440025"),
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440026"),
+		uuid.MustParse("550e8400-e29b-41d4-a716-446655440027"),
+	}
+	// The middle two share a createdAt, so ID decides their order
+	createdAt := []int64{100, 200, 200, 300}
+	for i, id := range ids {
+		h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+			ID:        id,
+			Title:     fmt.Sprintf("Step %d", i),
+			Completed: i == 3,
+			CreatedAt: createdAt[i],
+			UpdatedAt: createdAt[i],
+		})
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantID     uuid.UUID
+		wantCode   errors.ErrorCode
+	}{
+		{"next", "/" + ids[0].String() + "/next", http.StatusOK, ids[1], ""},
+		{"next within the same second", "/" + ids[1].String() + "/next", http.StatusOK, ids[2], ""},
+		{"next after a tie", "/" + ids[2].String() + "/next", http.StatusOK, ids[3], ""},
+		{"next at the end", "/" + ids[3].String() + "/next", http.StatusNotFound, uuid.Nil, errors.CodeNoAdjacentTask},
+		{"prev", "/" + ids[3].String() + "/prev", http.StatusOK, ids[2], ""},
+		{"prev within the same second", "/" + ids[2].String() + "/prev", http.StatusOK, ids[1], ""},
+		{"prev at the start", "/" + ids[0].String() + "/prev", http.StatusNotFound, uuid.Nil, errors.CodeNoAdjacentTask},
+		{"filters apply", "/" + ids[2].String() + "/next?completed=false", http.StatusNotFound, uuid.Nil, errors.CodeNoAdjacentTask},
+		{"sort rejected", "/" + ids[0].String() + "/next?sort=title", http.StatusBadRequest, uuid.Nil, errors.CodeInvalidQuery},
+		{"missing task", "/" + uuid.New().String() + "/next", http.StatusNotFound, uuid.Nil, errors.CodeTaskNotFound},
+		{"invalid ID", "/not-a-uuid/prev", http.StatusBadRequest, uuid.Nil, errors.CodeInvalidTaskID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks"+tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			if tt.wantCode != "" {
+				var apiErr errors.APIError
+				if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil {
+					t.Fatalf("failed to unmarshal error: %v", err)
+				}
+				if apiErr.Code != tt.wantCode {
+					t.Errorf("expected code %s, got %s", tt.wantCode, apiErr.Code)
+				}
+				return
+			}
+
+			var response tasks.GetTaskResponse
+			if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Task.Id != tt.wantID.String() {
+				t.Errorf("expected task %s, got %s", tt.wantID, response.Task.Id)
+			}
+		})
+	}
+}
+
+// TestIntegrationEnvelope tests that ?envelope=false unwraps single tasks and lists
+func TestIntegrationEnvelope(t *testing.T) {
+	router, h := setupRouter()
+
+	taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440028")
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:        taskUUID,
+		Title:     "Wrapped",
+		CreatedAt: 1234567890,
+		UpdatedAt: 1234567890,
+	})
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("single task", func(t *testing.T) {
+		for _, query := range []string{"", "?envelope=true", "?envelope=yes"} {
+			var response tasks.GetTaskResponse
+			w := serve(http.MethodGet, "/api/v1/tasks/"+taskUUID.String()+query, "")
+			if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Task.GetId() != taskUUID.String() {
+				t.Errorf("%q: expected the enveloped task, got %s", query, w.Body.String())
+			}
+		}
+
+		var task tasks.Task
+		w := serve(http.MethodGet, "/api/v1/tasks/"+taskUUID.String()+"?envelope=false", "")
+		if err := protojson.Unmarshal(w.Body.Bytes(), &task); err != nil || task.Id != taskUUID.String() {
+			t.Errorf("expected the bare task, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("create", func(t *testing.T) {
+		var task tasks.Task
+		w := serve(http.MethodPost, "/api/v1/tasks?envelope=false", `{"title":"Bare"}`)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d", w.Code)
+		}
+		if err := protojson.Unmarshal(w.Body.Bytes(), &task); err != nil || task.Title != "Bare" {
+			t.Errorf("expected the bare created task, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		var response tasks.ListTasksResponse
+		w := serve(http.MethodGet, "/api/v1/tasks", "")
+		if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil || len(response.Tasks) != 2 {
+			t.Errorf("expected the enveloped list, got %s", w.Body.String())
+		}
+
+		var list []json.RawMessage
+		w = serve(http.MethodGet, "/api/v1/tasks?envelope=false", "")
+		if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list) != 2 {
+			t.Fatalf("expected a bare array of 2 tasks, got %s", w.Body.String())
+		}
+		for _, raw := range list {
+			var task tasks.Task
+			if err := protojson.Unmarshal(raw, &task); err != nil || task.Id == "" {
+				t.Errorf("expected a task, got %s", raw)
+			}
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		w := serve(http.MethodGet, "/api/v1/tasks?envelope=false&completed=true", "")
+		if strings.TrimSpace(w.Body.String()) != "[]" {
+			t.Errorf("expected an empty array, got %s", w.Body.String())
+		}
+	})
+}
+
+// TestIntegrationCountBy tests grouped counts, filters and the field whitelist
+func TestIntegrationCountBy(t *testing.T) {
+	router, h := setupRouter()
+
+	alice := "alice"
+	seed := []database.Task{
+		{Title: "One", Completed: true, AssigneeID: &alice},
+		{Title: "Two", Completed: true},
+		{Title: "Three", AssigneeID: &alice},
+		{Title: "Four", Archived: true},
+	}
+	for _, task := range seed {
+		task.ID = uuid.New()
+		task.CreatedAt = 1234567890
+		task.UpdatedAt = 1234567890
+		h.db.GetTaskRepository().Create(context.Background(), &task)
+	}
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		want       map[string]int64
+	}{
+		{"?field=completed", http.StatusOK, map[string]int64{"true": 2, "false": 1}},
+		{"?field=assignee", http.StatusOK, map[string]int64{"alice": 2, "": 1}},
+		{"?field=archived&archived=all", http.StatusOK, map[string]int64{"true": 1, "false": 3}},
+		{"?field=completed&assignee=alice", http.StatusOK, map[string]int64{"true": 1, "false": 1}},
+		{"?field=title", http.StatusBadRequest, nil},
+		{"", http.StatusBadRequest, nil},
+		{"?field=completed&archived=maybe", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/count-by"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.want == nil {
+				return
+			}
+
+			var got map[string]int64
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestIntegrationLongPoll tests that ?wait= holds an empty list until a change arrives
+func TestIntegrationLongPoll(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	notifier := events.NewChangeNotifier()
+	h := NewTaskHandler(NewMockDatabase(), logger,
+		WithClock(NewFakeClock(time.Unix(1234567900, 0))),
+		WithEventPublisher(notifier),
+		WithChangeNotifier(notifier))
+
+	router := chi.NewRouter()
+	router.Get("/api/v1/tasks", h.GetAll)
+	router.Post("/api/v1/tasks", h.Create)
+
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:        uuid.MustParse("550e8400-e29b-41d4-a716-446655440029"),
+		Title:     "Old news",
+		CreatedAt: 1234567890,
+		UpdatedAt: 1234567890,
+	})
+
+	list := func(ctx context.Context, query string) (*httptest.ResponseRecorder, []*tasks.Task) {
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/tasks"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response tasks.ListTasksResponse
+		if w.Code == http.StatusOK && w.Body.Len() > 0 {
+			if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Errorf("failed to unmarshal response: %v", err)
+			}
+		}
+		return w, response.Tasks
+	}
+
+	t.Run("updated_since filters without waiting", func(t *testing.T) {
+		w, got := list(context.Background(), "?updated_since=1234567889")
+		if w.Code != http.StatusOK || len(got) != 1 {
+			t.Fatalf("expected 1 task, got status %d with %d tasks", w.Code, len(got))
+		}
+	})
+
+	t.Run("times out with an empty list", func(t *testing.T) {
+		w, got := list(context.Background(), "?updated_since=1234567890&wait=20ms")
+		if w.Code != http.StatusOK || len(got) != 0 {
+			t.Fatalf("expected an empty list, got status %d with %d tasks", w.Code, len(got))
+		}
+	})
+
+	t.Run("invalid wait", func(t *testing.T) {
+		for _, wait := range []string{"soon", "-1s", "2m"} {
+			if w, _ := list(context.Background(), "?wait="+wait); w.Code != http.StatusBadRequest {
+				t.Errorf("wait=%s: expected status 400, got %d", wait, w.Code)
+			}
+		}
+	})
+
+	t.Run("wakes on a change", func(t *testing.T) {
+		done := make(chan []*tasks.Task)
+		go func() {
+			_, got := list(context.Background(), "?updated_since=1234567890&wait=10s")
+			done <- got
+		}()
+
+		// Whether the create lands before or during the wait, it is returned
+		time.Sleep(20 * time.Millisecond)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", strings.NewReader(`{"title":"Fresh"}`))
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		select {
+		case got := <-done:
+			if len(got) != 1 || got[0].Title != "Fresh" {
+				t.Errorf("expected only the new task, got %v", got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("long poll did not wake up")
+		}
+	})
+
+	t.Run("client disconnect", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		w, _ := list(ctx, "?updated_since=1234567999&wait=10s")
+		if w.Body.Len() != 0 {
+			t.Errorf("expected no response body, got %s", w.Body.String())
+		}
+	})
+}
+
+// TestIntegrationSeed tests that seeding replaces every task and is refused when disabled or unauthorized
+func TestIntegrationSeed(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		router, _ := setupRouter()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/seed", nil)
+		req.Header.Set("Authorization", "Bearer anything")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403, got %d", w.Code)
+		}
+		var apiErr errors.APIError
+		json.Unmarshal(w.Body.Bytes(), &apiErr)
+		if apiErr.Code != errors.CodeSeedDisabled {
+			t.Errorf("expected code %s, got %s", errors.CodeSeedDisabled, apiErr.Code)
+		}
+	})
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(NewMockDatabase(), logger, WithSeedToken("s3cret"), WithRequiredFields([]string{"description"}))
+	router := chi.NewRouter()
+	router.Post("/api/v1/admin/seed", h.Seed)
+
+	oldID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440030")
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:        oldID,
+		Title:     "Left over",
+		CreatedAt: 1234567890,
+		UpdatedAt: 1234567890,
+	})
+
+	seed := func(token, body string) (int, []*tasks.Task) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/seed", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response tasks.ListTasksResponse
+		if w.Code == http.StatusCreated {
+			if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+		}
+		return w.Code, response.Tasks
+	}
+
+	for _, token := range []string{"", "wrong"} {
+		if status, _ := seed(token, ""); status != http.StatusUnauthorized {
+			t.Errorf("token %q: expected status 401, got %d", token, status)
+		}
+	}
+
+	if status, _ := seed("s3cret", `{"tasks":[{"title":""}]}`); status != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422 for an invalid task, got %d", status)
+	}
+	if status, _ := seed("s3cret", `{"tasks":[{"title":"No description"}]}`); status != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422 for a task missing a required field, got %d", status)
+	}
+	if status, _ := seed("s3cret", `{"tasks":[{"title":"Blocked","description":"By nothing","blockedBy":["`+oldID.String()+`"]}]}`); status != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a task with blockers, got %d", status)
+	}
+	if task, _ := h.db.GetTaskRepository().FindByID(context.Background(), oldID); task == nil {
+		t.Error("expected a rejected seed to leave existing tasks alone")
+	}
+
+	status, seeded := seed("s3cret", "")
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", status)
+	}
+	if len(seeded) != len(sampleTasks) {
+		t.Errorf("expected %d sample tasks, got %d", len(sampleTasks), len(seeded))
+	}
+	if task, _ := h.db.GetTaskRepository().FindByID(context.Background(), oldID); task != nil {
+		t.Error("expected seeding to delete existing tasks")
+	}
+
+	status, seeded = seed("s3cret", `{"tasks":[{"title":"Only one","description":"From the request"}]}`)
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", status)
+	}
+	all, _ := h.db.GetTaskRepository().FindAll(context.Background(), database.TaskQuery{})
+	if len(seeded) != 1 || len(all) != 1 || all[0].Title != "Only one" {
+		t.Errorf("expected only the requested task, got %d seeded and %d stored", len(seeded), len(all))
+	}
+}
+
+// TestIntegrationCreateGzip tests creating a task from a gzipped request body
+func TestIntegrationCreateGzip(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(NewMockDatabase(), logger)
+
+	r := chi.NewRouter()
+	r.With(middleware.Decompress([]string{"gzip"}, 1<<20)).Post("/api/v1/tasks", h.Create)
+
+	var body bytes.Buffer
+	gz := gzip.NewWriter(&body)
+	gz.Write([]byte(`{"title":"Sent compressed","description":"Over a slow link"}`))
+	gz.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", &body)
+	req.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response tasks.GetTaskResponse
+	if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if response.Task.Title != "Sent compressed" || response.Task.Description != "Over a slow link" {
+		t.Errorf("expected the decoded task, got %v", response.Task)
+	}
+}
+
+// TestIntegrationListOrder tests that the list comes back in the same documented order every time
+func TestIntegrationListOrder(t *testing.T) {
+	router, h := setupRouter()
+
+	// Tasks created in the same second are ordered by ID, whatever the insertion order
+	createdAt := []int64{200, 100, 200, 100, 200}
+	for i, seconds := range createdAt {
+		h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+			ID:        uuid.MustParse(fmt.Sprintf("550e8400-e29b-41d4-a716-4466554400%d", 35-i)),
+			Title:     "Task",
+			CreatedAt: seconds,
+			UpdatedAt: seconds,
+		})
+	}
+	want := []string{
+		"550e8400-e29b-41d4-a716-446655440032",
+		"550e8400-e29b-41d4-a716-446655440034",
+		"550e8400-e29b-41d4-a716-446655440031",
+		"550e8400-e29b-41d4-a716-446655440033",
+		"550e8400-e29b-41d4-a716-446655440035",
+	}
+
+	for range 5 {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response tasks.ListTasksResponse
+		if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		got := make([]string, len(response.Tasks))
+		for i, task := range response.Tasks {
+			got[i] = task.Id
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("expected order %v, got %v", want, got)
+		}
+	}
+}
+
+// TestIntegrationFilterDSL tests the ?filter= parameter on the list
+func TestIntegrationFilterDSL(t *testing.T) {
+	router, h := setupRouter()
+
+	alice := "alice"
+	completedAt := int64(1500)
+	seed := []database.Task{
+		{Title: "Done by alice", Completed: true, CompletedAt: &completedAt, AssigneeID: &alice, CreatedAt: 1000},
+		{Title: "Open for alice", AssigneeID: &alice, CreatedAt: 2000},
+		{Title: "Open", CreatedAt: 3000},
+	}
+	for _, task := range seed {
+		task.ID = uuid.New()
+		task.UpdatedAt = task.CreatedAt
+		h.db.GetTaskRepository().Create(context.Background(), &task)
+	}
+
+	tests := []struct {
+		filter     string
+		wantStatus int
+		wantTitles []string
+	}{
+		{"completed:false", http.StatusOK, []string{"Open for alice", "Open"}},
+		{"assignee:alice,completed:false", http.StatusOK, []string{"Open for alice"}},
+		{"createdAt:gte:2000", http.StatusOK, []string{"Open for alice", "Open"}},
+		{"completedAt:lt:2000", http.StatusOK, []string{"Done by alice"}},
+		{"assignee:ne:alice", http.StatusOK, []string{"Open"}},
+		{"priority:HIGH", http.StatusBadRequest, nil},
+		{"$where:1", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks?filter="+url.QueryEscape(tt.filter), nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response tasks.ListTasksResponse
+			if err := protojson.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			got := make([]string, len(response.Tasks))
+			for i, task := range response.Tasks {
+				got[i] = task.Title
+			}
+			if !slices.Equal(got, tt.wantTitles) {
+				t.Errorf("expected %v, got %v", tt.wantTitles, got)
+			}
+		})
+	}
+}
+
+// TestIntegrationExists tests the existence check endpoint
+func TestIntegrationExists(t *testing.T) {
+	router, h := setupRouter()
+
+	testID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440036")
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{ID: testID, Title: "Exists", CreatedAt: 1000, UpdatedAt: 1000})
+
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+		wantBody   string
+	}{
+		{"existing task", testID.String(), http.StatusOK, `{"exists":true}`},
+		{"missing task", uuid.New().String(), http.StatusOK, `{"exists":false}`},
+		{"invalid ID", "not-a-uuid", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/"+tt.id+"/exists", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("expected body %s, got %s", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestIntegrationCompletionTrend tests the per-day completion counts and their time zone
+func TestIntegrationCompletionTrend(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	now := time.Date(2025, 11, 13, 10, 0, 0, 0, time.UTC)
+	h := NewTaskHandler(NewMockDatabase(), logger, WithClock(NewFakeClock(now)))
+
+	router := chi.NewRouter()
+	router.Get("/api/v1/tasks/completion-trend", h.CompletionTrend)
+
+	completions := []time.Time{
+		time.Date(2025, 11, 13, 1, 0, 0, 0, time.UTC), // still Nov 12 in New York
+		time.Date(2025, 11, 11, 12, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC), // outside the window
+	}
+	for _, at := range completions {
+		completedAt := at.Unix()
+		h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+			ID:          uuid.New(),
+			Title:       "Done",
+			Completed:   true,
+			CompletedAt: &completedAt,
+			CreatedAt:   completedAt,
+			UpdatedAt:   completedAt,
+		})
+	}
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{ID: uuid.New(), Title: "Open", CreatedAt: now.Unix(), UpdatedAt: now.Unix()})
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		want       trendResponse
+	}{
+		{"?days=3", http.StatusOK, trendResponse{Timezone: "UTC", Days: []trendDay{
+			{"2025-11-11", 1}, {"2025-11-12", 0}, {"2025-11-13", 1},
+		}}},
+		{"?days=3&tz=America/New_York", http.StatusOK, trendResponse{Timezone: "America/New_York", Days: []trendDay{
+			{"2025-11-11", 1}, {"2025-11-12", 1}, {"2025-11-13", 0},
+		}}},
+		{"?days=0", http.StatusBadRequest, trendResponse{}},
+		{"?tz=Mars/Olympus", http.StatusBadRequest, trendResponse{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/completion-trend"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var got trendResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if got.Timezone != tt.want.Timezone || !slices.Equal(got.Days, tt.want.Days) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestIntegrationFieldSelection tests that ?fields= trims tasks to the named fields
+func TestIntegrationFieldSelection(t *testing.T) {
+	router, h := setupRouter()
+
+	testID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440037")
+	expiresAt := time.Now().Add(time.Hour)
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID:          testID,
+		Title:       "Trimmed",
+		Description: "A long description nobody asked for",
+		Completed:   true,
+		CreatedAt:   1000,
+		UpdatedAt:   1000,
+		ExpiresAt:   &expiresAt,
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantKeys   []string
+	}{
+		{"list", "/api/v1/tasks?completed=all&envelope=false&fields=title", http.StatusOK, []string{"id", "title"}},
+		{"single", "/api/v1/tasks/" + testID.String() + "?envelope=false&fields=completed,createdAt", http.StatusOK, []string{"completed", "createdAt", "id"}},
+		{"expiry", "/api/v1/tasks/" + testID.String() + "?envelope=false&fields=expiresAt", http.StatusOK, []string{"expiresAt", "id"}},
+		{"unknown field", "/api/v1/tasks?fields=title,secret", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			body := bytes.TrimSpace(w.Body.Bytes())
+			if body[0] == '[' {
+				body = bytes.TrimSuffix(bytes.TrimPrefix(body, []byte("[")), []byte("]"))
+			}
+			var task map[string]any
+			if err := json.Unmarshal(body, &task); err != nil {
+				t.Fatalf("failed to unmarshal task: %v", err)
+			}
+			if got := slices.Sorted(maps.Keys(task)); !slices.Equal(got, tt.wantKeys) {
+				t.Errorf("expected fields %v, got %v", tt.wantKeys, got)
+			}
+		})
+	}
+}
+
+// TestIntegrationUpdateIfUnmodifiedSince tests that PUT honors If-Unmodified-Since
+func TestIntegrationUpdateIfUnmodifiedSince(t *testing.T) {
+	router, h := setupRouter()
+
+	testID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440038")
+	updatedAt := time.Date(2025, 11, 13, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		id         uuid.UUID
+		header     string
+		wantStatus int
+		wantCode   errors.ErrorCode
+	}{
+		{"unmodified", testID, updatedAt.Format(http.TimeFormat), http.StatusOK, ""},
+		{"modified since", testID, updatedAt.Add(-time.Second).Format(http.TimeFormat), http.StatusPreconditionFailed, errors.CodeTaskModified},
+		{"unparsable date is ignored", testID, "yesterday", http.StatusOK, ""},
+		{"missing task", uuid.New(), updatedAt.Format(http.TimeFormat), http.StatusNotFound, errors.CodeTaskNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h.db.GetTaskRepository().Delete(context.Background(), testID)
+			h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+				ID:        testID,
+				Title:     "Original",
+				CreatedAt: updatedAt.Unix(),
+				UpdatedAt: updatedAt.Unix(),
+			})
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/"+tt.id.String(), strings.NewReader(`{"title":"Changed"}`))
+			req.Header.Set("If-Unmodified-Since", tt.header)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			stored, _ := h.db.GetTaskRepository().FindByID(context.Background(), testID)
+			if changed := stored.Title == "Changed"; changed != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("unexpected stored title %q", stored.Title)
+			}
+
+			if tt.wantCode != "" {
+				var apiErr errors.APIError
+				if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil {
+					t.Fatalf("failed to unmarshal error: %v", err)
+				}
+				if apiErr.Code != tt.wantCode {
+					t.Errorf("expected code %s, got %s", tt.wantCode, apiErr.Code)
+				}
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/"+testID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if got := w.Header().Get("Last-Modified"); got != updatedAt.Format(http.TimeFormat) {
+		t.Errorf("expected Last-Modified %q, got %q", updatedAt.Format(http.TimeFormat), got)
+	}
+}
+
+// TestIntegrationSync tests that a sync push inserts new tasks, replaces older
+// stored copies and reports newer ones as conflicts without writing them
+func TestIntegrationSync(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	mockDB := NewMockDatabase()
+	h := NewTaskHandler(mockDB, logger, WithClock(NewFakeClock(time.Date(2025, 11, 13, 12, 0, 0, 0, time.UTC))))
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/sync", h.Sync)
+
+	newID := "550e8400-e29b-41d4-a716-446655440039"
+	olderID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440040")
+	newerID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440041")
+
+	repo := mockDB.GetTaskRepository()
+	// Updated at 2025-11-13T09:00:00Z and 11:00:00Z, either side of the pushed copies
+	repo.Create(context.Background(), &database.Task{ID: olderID, Title: "Stale", Archived: true, CreatedAt: 1763010000, UpdatedAt: 1763024400})
+	repo.Create(context.Background(), &database.Task{ID: newerID, Title: "Server edit", CreatedAt: 1763010000, UpdatedAt: 1763031600})
+
+	body := `{"tasks": [
+		{"id": "` + newID + `", "title": "Offline", "completed": true, "createdAt": "2025-11-13T10:00:00Z", "updatedAt": "2025-11-13T10:30:00Z"},
+		{"id": "` + olderID.String() + `", "title": "Client edit", "createdAt": "2025-11-13T08:00:00Z", "updatedAt": "2025-11-13T10:00:00Z"},
+		{"id": "` + newerID.String() + `", "title": "Client edit", "createdAt": "2025-11-13T09:00:00Z", "updatedAt": "2025-11-13T10:00:00Z"},
+		{"id": "` + newID + `", "title": "Again", "createdAt": "2025-11-13T10:00:00Z", "updatedAt": "2025-11-13T10:30:00Z"},
+		{"id": "550e8400-e29b-41d4-a716-446655440042", "title": "Ahead", "createdAt": "2025-11-13T10:00:00Z", "updatedAt": "2025-11-14T10:00:00Z"},
+		{"id": "550e8400-e29b-41d4-a716-446655440043", "title": "", "createdAt": "2025-11-13T10:00:00Z", "updatedAt": "2025-11-13T10:00:00Z"}
+	]}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sync", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("expected status 207, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Results []struct {
+			Status int             `json:"status"`
+			Task   json.RawMessage `json:"task"`
+			Error  *struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	wantResults := []struct {
+		status int
+		code   string
+	}{
+		{http.StatusCreated, ""},
+		{http.StatusOK, ""},
+		{http.StatusConflict, "SYNC_CONFLICT"},
+		{http.StatusUnprocessableEntity, "BATCH_DUPLICATE_ID"},
+		{http.StatusUnprocessableEntity, "SYNC_VERSION_INVALID"},
+		{http.StatusUnprocessableEntity, "TASK_TITLE_REQUIRED"},
+	}
+	if len(resp.Results) != len(wantResults) {
+		t.Fatalf("expected %d results, got %d", len(wantResults), len(resp.Results))
+	}
+	for i, want := range wantResults {
+		got := resp.Results[i]
+		code := ""
+		if got.Error != nil {
+			code = got.Error.Code
+		}
+		if got.Status != want.status || code != want.code {
+			t.Errorf("result %d: expected %d %q, got %d %q", i, want.status, want.code, got.Status, code)
+		}
+	}
+
+	var conflict tasks.Task
+	if err := protojson.Unmarshal(resp.Results[2].Task, &conflict); err != nil {
+		t.Fatalf("failed to decode conflicting task: %v", err)
+	}
+	if conflict.Title != "Server edit" {
+		t.Errorf("expected the server's copy with the conflict, got %q", conflict.Title)
+	}
+
+	inserted, _ := repo.FindByID(context.Background(), uuid.MustParse(newID))
+	if inserted == nil || inserted.Title != "Offline" || inserted.CompletedAt == nil || *inserted.CompletedAt != 1763029800 {
+		t.Errorf("expected the new task with completedAt from updatedAt, got %+v", inserted)
+	}
+
+	updated, _ := repo.FindByID(context.Background(), olderID)
+	if updated.Title != "Client edit" || updated.CreatedAt != 1763010000 || !updated.Archived {
+		t.Errorf("expected the client's edit with the stored createdAt and archived flag, got %+v", updated)
+	}
+
+	kept, _ := repo.FindByID(context.Background(), newerID)
+	if kept.Title != "Server edit" {
+		t.Errorf("expected the newer server copy to be kept, got %q", kept.Title)
+	}
+}
+
+// TestIntegrationSyncBlockers tests that pushed blockers are held to the
+// rules of an update
+func TestIntegrationSyncBlockers(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	mockDB := NewMockDatabase()
+	h := NewTaskHandler(mockDB, logger, WithClock(NewFakeClock(time.Date(2025, 11, 13, 12, 0, 0, 0, time.UTC))))
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/sync", h.Sync)
+
+	blockerID := "550e8400-e29b-41d4-a716-446655440049"
+	repo := mockDB.GetTaskRepository()
+	repo.Create(context.Background(), &database.Task{ID: uuid.MustParse(blockerID), Title: "Open blocker", CreatedAt: 1763010000, UpdatedAt: 1763010000})
+
+	ids := []string{
+		"550e8400-e29b-41d4-a716-446655440050",
+		"550e8400-e29b-41d4-a716-446655440051",
+		"550e8400-e29b-41d4-a716-446655440052",
+		"550e8400-e29b-41d4-a716-446655440053",
+	}
+	item := func(id, blockedBy string, completed bool) string {
+		return fmt.Sprintf(`{"id": %q, "title": "Offline", "completed": %v, "blockedBy": [%q], "createdAt": "2025-11-13T10:00:00Z", "updatedAt": "2025-11-13T10:30:00Z"}`, id, completed, blockedBy)
+	}
+	body := `{"tasks": [` + strings.Join([]string{
+		item(ids[0], uuid.NewString(), false),
+		item(ids[1], ids[1], false),
+		item(ids[2], blockerID, true),
+		item(ids[3], blockerID, false),
+	}, ",") + `]}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sync", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("expected status 207, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Results []struct {
+			Status int `json:"status"`
+			Error  *struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	wantResults := []struct {
+		status int
+		code   string
+	}{
+		{http.StatusUnprocessableEntity, "TASK_BLOCKER_NOT_FOUND"},
+		{http.StatusUnprocessableEntity, "TASK_BLOCKER_CYCLE"},
+		{http.StatusUnprocessableEntity, "TASK_BLOCKED"},
+		{http.StatusCreated, ""},
+	}
+	if len(resp.Results) != len(wantResults) {
+		t.Fatalf("expected %d results, got %d", len(wantResults), len(resp.Results))
+	}
+	for i, want := range wantResults {
+		got := resp.Results[i]
+		code := ""
+		if got.Error != nil {
+			code = got.Error.Code
+		}
+		if got.Status != want.status || code != want.code {
+			t.Errorf("result %d: expected %d %q, got %d %q", i, want.status, want.code, got.Status, code)
+		}
+
+		stored, _ := repo.FindByID(context.Background(), uuid.MustParse(ids[i]))
+		if (stored != nil) != (want.code == "") {
+			t.Errorf("result %d: expected stored %v, got %+v", i, want.code == "", stored)
+		}
+	}
+}
+
+// TestIntegrationExpiry tests setting an expiry on create, and replacing and
+// clearing it on update
+func TestIntegrationExpiry(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(NewMockDatabase(), logger, WithClock(NewFakeClock(time.Date(2025, 11, 13, 10, 0, 0, 0, time.UTC))))
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/tasks", h.Create)
+	router.Put("/api/v1/tasks/{id}", h.Update)
+
+	send := func(method, path, body string) (*httptest.ResponseRecorder, *tasks.Task) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var resp tasks.GetTaskResponse
+		if w.Code < 300 {
+			if err := protojson.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+		}
+		return w, resp.Task
+	}
+
+	w, task := send(http.MethodPost, "/api/v1/tasks", `{"title": "Ephemeral", "expiresAt": "2025-11-14T10:00:00.750Z"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := task.GetExpiresAt().AsTime(); !got.Equal(time.Date(2025, 11, 14, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the expiry in whole seconds, got %v", got)
+	}
+
+	w, _ = send(http.MethodPost, "/api/v1/tasks", `{"title": "Expired", "expiresAt": "2025-11-13T10:00:00Z"}`)
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), string(errors.CodeExpiryInvalid)) {
+		t.Errorf("expected 422 %s for an expiry that is not in the future, got %d: %s", errors.CodeExpiryInvalid, w.Code, w.Body.String())
+	}
+
+	path := "/api/v1/tasks/" + task.Id
+	w, task = send(http.MethodPut, path, `{"title": "Ephemeral", "expiresAt": "2025-11-20T00:00:00Z"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := task.GetExpiresAt().AsTime(); !got.Equal(time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the expiry to be replaced, got %v", got)
+	}
+
+	w, task = send(http.MethodPut, path, `{"title": "Kept"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if task.ExpiresAt != nil {
+		t.Errorf("expected an update without expiresAt to clear it, got %v", task.ExpiresAt.AsTime())
+	}
+}
+
+// TestIntegrationUpdateDescription tests that an update keeps, clears or sets the description
+func TestIntegrationUpdateDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"omitted keeps it", `{"title":"Renamed"}`, "Original Description"},
+		{"empty clears it", `{"title":"Renamed","description":""}`, ""},
+		{"value sets it", `{"title":"Renamed","description":"New Description"}`, "New Description"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, h := setupRouter()
+
+			taskUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440044")
+			h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+				ID:          taskUUID,
+				Title:       "Original Title",
+				Description: "Original Description",
+				CreatedAt:   1234567890,
+				UpdatedAt:   1234567890,
+			})
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/"+taskUUID.String(), strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			stored, _ := h.db.GetTaskRepository().FindByID(context.Background(), taskUUID)
+			if stored.Description != tt.want {
+				t.Errorf("expected description %q, got %q", tt.want, stored.Description)
+			}
+			if stored.Title != "Renamed" {
+				t.Errorf("expected title 'Renamed', got %q", stored.Title)
+			}
+		})
+	}
+}
+
+// TestIntegrationBlockersOnCompletedTask tests that PUT, like PATCH, refuses
+// open blockers on a completed task even when the request leaves out completed
+func TestIntegrationBlockersOnCompletedTask(t *testing.T) {
+	router, h := setupRouter()
+
+	open := uuid.MustParse("550e8400-e29b-41d4-a716-446655440057")
+	done := uuid.MustParse("550e8400-e29b-41d4-a716-446655440058")
+	completedAt := int64(1234567891)
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID: open, Title: "Open", CreatedAt: 1234567890, UpdatedAt: 1234567890,
+	})
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID: done, Title: "Done", Completed: true, CompletedAt: &completedAt, CreatedAt: 1234567891, UpdatedAt: 1234567891,
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+	}{
+		{"put", http.MethodPut, "application/json", `{"title":"Done","blockedBy":["` + open.String() + `"]}`},
+		{"patch", http.MethodPatch, jsonPatchContentType, `[{"op":"add","path":"/blockedBy","value":["` + open.String() + `"]}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/tasks/"+done.String(), strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), string(errors.CodeTaskBlocked)) {
+				t.Errorf("expected 422 %s, got %d: %s", errors.CodeTaskBlocked, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	stored, _ := h.db.GetTaskRepository().FindByID(context.Background(), done)
+	if len(stored.BlockedBy) != 0 || !stored.Completed {
+		t.Errorf("expected the completed task left alone, got %+v", stored)
+	}
+
+	// An open task may take open blockers without saying it stays open
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/"+open.String(), strings.NewReader(`{"title":"Open","blockedBy":["`+done.String()+`"]}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for an open task, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestIntegrationBlockers tests that blockers must exist, cannot form a cycle
+// and keep a task open until they are completed
+func TestIntegrationBlockers(t *testing.T) {
+	router, h := setupRouter()
+
+	blocker := uuid.MustParse("550e8400-e29b-41d4-a716-446655440045")
+	blocked := uuid.MustParse("550e8400-e29b-41d4-a716-446655440046")
+	missing := uuid.MustParse("550e8400-e29b-41d4-a716-446655440047")
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID: blocker, Title: "Blocker", CreatedAt: 1234567890, UpdatedAt: 1234567890,
+	})
+	h.db.GetTaskRepository().Create(context.Background(), &database.Task{
+		ID: blocked, Title: "Blocked", BlockedBy: []uuid.UUID{blocker}, CreatedAt: 1234567891, UpdatedAt: 1234567891,
+	})
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	rejected := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   errors.ErrorCode
+	}{
+		{"missing blocker", http.MethodPost, "/api/v1/tasks", `{"title":"New","blockedBy":["` + missing.String() + `"]}`, errors.CodeBlockerNotFound},
+		{"blocked by itself", http.MethodPut, "/api/v1/tasks/" + blocker.String(), `{"title":"Blocker","blockedBy":["` + blocker.String() + `"]}`, errors.CodeBlockerCycle},
+		{"cycle", http.MethodPut, "/api/v1/tasks/" + blocker.String(), `{"title":"Blocker","blockedBy":["` + blocked.String() + `"]}`, errors.CodeBlockerCycle},
+		{"complete while blocked", http.MethodPost, "/api/v1/tasks/" + blocked.String() + "/complete", "", errors.CodeTaskBlocked},
+		{"update to completed while blocked", http.MethodPut, "/api/v1/tasks/" + blocked.String(), `{"title":"Blocked","completed":true,"blockedBy":["` + blocker.String() + `"]}`, errors.CodeTaskBlocked},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			w := send(tt.method, tt.path, tt.body)
+			if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), string(tt.code)) {
+				t.Errorf("expected 422 %s, got %d: %s", tt.code, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	w := send(http.MethodPost, "/api/v1/tasks", `{"title":"New","blockedBy":["`+blocker.String()+`"]}`)
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), blocker.String()) {
+		t.Errorf("expected 201 with the blocker, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = send(http.MethodGet, "/api/v1/tasks/"+blocked.String()+"/blockers", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var list tasks.ListTasksResponse
+	if err := protojson.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Tasks) != 1 || list.Tasks[0].Id != blocker.String() {
+		t.Errorf("expected the blocker, got %v", list.Tasks)
+	}
+
+	if w := send(http.MethodPost, "/api/v1/tasks/"+blocker.String()+"/complete", ""); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 completing the blocker, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/api/v1/tasks/"+blocked.String()+"/complete", ""); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 once the blocker is completed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := send(http.MethodGet, "/api/v1/tasks/"+missing.String()+"/blockers", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for a missing task, got %d", w.Code)
+	}
+}
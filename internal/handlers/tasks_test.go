@@ -3,14 +3,19 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	tasks "github.com/PinceredCoder/restGo/api/proto/v1"
 	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/errors"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -201,24 +206,35 @@ func TestCreateValidation(t *testing.T) {
 		title       string
 		description string
 		wantStatus  int
+		wantCode    errors.ErrorCode
 	}{
 		{
 			name:        "empty title",
 			title:       "",
 			description: "Valid description",
-			wantStatus:  http.StatusBadRequest,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    errors.CodeTitleRequired,
 		},
 		{
 			name:        "title too long",
 			title:       string(make([]byte, 101)), // 101 chars
 			description: "Valid description",
-			wantStatus:  http.StatusBadRequest,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    errors.CodeTitleTooLong,
 		},
 		{
 			name:        "description too long",
 			title:       "Valid title",
 			description: string(make([]byte, 501)), // 501 chars
-			wantStatus:  http.StatusBadRequest,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    errors.CodeDescriptionTooLong,
+		},
+		{
+			name:        "control character",
+			title:       "Valid\x00title",
+			description: "Valid description",
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    errors.CodeControlCharacters,
 		},
 	}
 
@@ -238,6 +254,126 @@ func TestCreateValidation(t *testing.T) {
 			if w.Code != tt.wantStatus {
 				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
 			}
+
+			var response errors.APIError
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if response.Type != errors.ErrorTypeValidation || response.Code != tt.wantCode {
+				t.Errorf("expected %s/%s, got %s/%s", errors.ErrorTypeValidation, tt.wantCode, response.Type, response.Code)
+			}
+		})
+	}
+}
+
+// TestCreateConfiguredLimits tests the configurable title and description limits
+func TestCreateConfiguredLimits(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(NewMockDatabase(), logger, WithFieldLimits(10, 200))
+
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		wantStatus  int
+		wantDetail  string
+	}{
+		{"title at limit", strings.Repeat("é", 10), "", http.StatusCreated, ""},
+		{"title over limit", strings.Repeat("a", 11), "", http.StatusUnprocessableEntity, "value length must be at most 10 runes"},
+		{"description above default", "Valid", strings.Repeat("a", 200), http.StatusCreated, ""},
+		{"description over limit", "Valid", strings.Repeat("a", 201), http.StatusUnprocessableEntity, "value length must be at most 200 runes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodyBytes, _ := protojson.Marshal(&tasks.CreateTaskRequest{
+				Title:       tt.title,
+				Description: tt.description,
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewReader(bodyBytes))
+			w := httptest.NewRecorder()
+
+			h.Create(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			if tt.wantDetail == "" {
+				return
+			}
+
+			var response struct {
+				Details []errors.ValidationErrorDetail `json:"details"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if len(response.Details) != 1 || response.Details[0].Message != tt.wantDetail {
+				t.Errorf("expected detail '%s', got %+v", tt.wantDetail, response.Details)
+			}
+		})
+	}
+}
+
+// TestCreateRequiredDescription tests that a required description is enforced
+// only when configured, and that blank values count as missing
+func TestCreateRequiredDescription(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+
+	tests := []struct {
+		name        string
+		required    []string
+		description string
+		wantStatus  int
+		wantCode    errors.ErrorCode
+	}{
+		{"optional and empty", nil, "", http.StatusCreated, ""},
+		{"required and present", []string{"description"}, "Details", http.StatusCreated, ""},
+		{"required and empty", []string{"description"}, "", http.StatusUnprocessableEntity, errors.CodeDescriptionRequired},
+		{"required and blank", []string{"description"}, " \n\t", http.StatusUnprocessableEntity, errors.CodeDescriptionRequired},
+		{"only title required", []string{"title"}, "", http.StatusCreated, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskHandler(NewMockDatabase(), logger, WithRequiredFields(tt.required))
+
+			bodyBytes, _ := protojson.Marshal(&tasks.CreateTaskRequest{
+				Title:       "Valid",
+				Description: tt.description,
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewReader(bodyBytes))
+			w := httptest.NewRecorder()
+
+			h.Create(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantCode == "" {
+				return
+			}
+
+			var response struct {
+				Code    errors.ErrorCode               `json:"code"`
+				Details []errors.ValidationErrorDetail `json:"details"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Code != tt.wantCode {
+				t.Errorf("expected code %s, got %s", tt.wantCode, response.Code)
+			}
+			if len(response.Details) != 1 || response.Details[0].Field != "Description" {
+				t.Errorf("expected one Description detail, got %+v", response.Details)
+			}
 		})
 	}
 }
@@ -256,6 +392,240 @@ func TestCreateInvalidJSON(t *testing.T) {
 	}
 }
 
+// TestCreateInvalidJSONDetails tests that parse errors report the field and position
+func TestCreateInvalidJSONDetails(t *testing.T) {
+	h := setupHandler()
+
+	tests := []struct {
+		name        string
+		body        string
+		wantField   string
+		wantMessage string
+		wantLine    int
+		wantColumn  int
+	}{
+		{"wrong type", `{"title": "x", "description": {}}`, "description", "invalid value for string field", 1, 31},
+		{"unknown field", `{"title": "x", "priority": 1}`, "priority", "unknown field", 1, 16},
+		{"multi-line", "{\n\"title\": 5\n}", "title", "invalid value for string field", 2, 10},
+		{"malformed", `{"title":"a",}`, "", "unexpected token }", 1, 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+
+			h.Create(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d", w.Code)
+			}
+
+			var response struct {
+				Type    string                 `json:"type"`
+				Message string                 `json:"message"`
+				Details errors.JSONErrorDetail `json:"details"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if response.Message != "Invalid JSON format" {
+				t.Errorf("expected message 'Invalid JSON format', got '%s'", response.Message)
+			}
+
+			want := errors.JSONErrorDetail{Field: tt.wantField, Message: tt.wantMessage, Line: tt.wantLine, Column: tt.wantColumn}
+			if response.Details != want {
+				t.Errorf("expected details %+v, got %+v", want, response.Details)
+			}
+		})
+	}
+}
+
+// TestCreateUnknownField tests that unknown members are rejected by name, or
+// ignored when the handler discards them
+func TestCreateUnknownField(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+
+	tests := []struct {
+		name       string
+		discard    bool
+		wantStatus int
+	}{
+		{"strict", false, http.StatusBadRequest},
+		{"lenient", true, http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskHandler(NewMockDatabase(), logger, WithDiscardUnknown(tt.discard))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", strings.NewReader(`{"title": "Task", "priority": "high"}`))
+			w := httptest.NewRecorder()
+
+			h.Create(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			if tt.discard {
+				var resp tasks.GetTaskResponse
+				if err := protojson.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+				if resp.Task.Title != "Task" {
+					t.Errorf("expected the known fields to be kept, got %q", resp.Task.Title)
+				}
+				return
+			}
+
+			var response struct {
+				Code    errors.ErrorCode       `json:"code"`
+				Details errors.JSONErrorDetail `json:"details"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Code != errors.CodeInvalidJSON || response.Details.Field != "priority" || response.Details.Message != "unknown field" {
+				t.Errorf("expected an unknown field error naming priority, got %+v", response)
+			}
+		})
+	}
+}
+
+// TestEmitUnset tests that unset fields are left out unless the handler emits
+// them, and that ?fields= still trims the task. The assignee has explicit
+// presence, so protojson leaves it out either way.
+func TestEmitUnset(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+
+	tests := []struct {
+		name string
+		emit bool
+		// request runs after a task without a description is created
+		path     string
+		wantKeys map[string]any
+		wantGone []string
+	}{
+		{"omitted", false, "/api/v1/tasks?envelope=false", map[string]any{"title": "Task"}, []string{"description", "completedAt", "assigneeId"}},
+		{"emitted", true, "/api/v1/tasks?envelope=false", map[string]any{"title": "Task", "description": "", "completedAt": nil, "completed": false}, []string{"assigneeId"}},
+		{"emitted but trimmed", true, "/api/v1/tasks?envelope=false&fields=title", map[string]any{"title": "Task"}, []string{"description", "completedAt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewTaskHandler(NewMockDatabase(), logger, WithEmitUnset(tt.emit))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", strings.NewReader(`{"title": "Task"}`))
+			w := httptest.NewRecorder()
+			h.Create(w, req)
+			if w.Code != http.StatusCreated {
+				t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+			}
+
+			req = httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w = httptest.NewRecorder()
+			h.GetAll(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var taskList []map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &taskList); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if len(taskList) != 1 {
+				t.Fatalf("expected 1 task, got %d", len(taskList))
+			}
+
+			task := taskList[0]
+			for key, want := range tt.wantKeys {
+				if got, ok := task[key]; !ok || got != want {
+					t.Errorf("expected %s to be %v, got %v (present: %v)", key, want, got, ok)
+				}
+			}
+			for _, key := range tt.wantGone {
+				if got, ok := task[key]; ok {
+					t.Errorf("expected %s to be left out, got %v", key, got)
+				}
+			}
+		})
+	}
+}
+
+// TestSchema tests that the request schema reflects the proto rules and configured limits
+func TestSchema(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	h := NewTaskHandler(NewMockDatabase(), logger, WithFieldLimits(10, 200))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/schema", nil)
+	w := httptest.NewRecorder()
+
+	h.Schema(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/schema+json" {
+		t.Errorf("expected Content-Type application/schema+json, got %s", contentType)
+	}
+
+	type property struct {
+		Type      string `json:"type"`
+		MinLength *int   `json:"minLength"`
+		MaxLength *int   `json:"maxLength"`
+	}
+	var schema struct {
+		Defs map[string]struct {
+			Properties           map[string]property `json:"properties"`
+			Required             []string            `json:"required"`
+			AdditionalProperties bool                `json:"additionalProperties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &schema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+
+	create, ok := schema.Defs["CreateTaskRequest"]
+	if !ok {
+		t.Fatal("expected a CreateTaskRequest definition")
+	}
+
+	title := create.Properties["title"]
+	if title.Type != "string" || title.MinLength == nil || *title.MinLength != 1 || title.MaxLength == nil || *title.MaxLength != 10 {
+		t.Errorf("unexpected title schema %+v", title)
+	}
+
+	if description := create.Properties["description"]; description.MaxLength == nil || *description.MaxLength != 200 {
+		t.Errorf("unexpected description schema %+v", description)
+	}
+
+	if len(create.Required) != 1 || create.Required[0] != "title" {
+		t.Errorf("expected only title to be required, got %v", create.Required)
+	}
+
+	if create.AdditionalProperties {
+		t.Error("expected unknown members to be rejected")
+	}
+
+	update, ok := schema.Defs["UpdateTaskRequest"]
+	if !ok {
+		t.Fatal("expected an UpdateTaskRequest definition")
+	}
+
+	if completed := update.Properties["completed"]; completed.Type != "boolean" {
+		t.Errorf("expected completed to be a boolean, got %+v", completed)
+	}
+}
+
 // TestGetByID tests retrieving a task by ID
 func TestGetByID(t *testing.T) {
 	h, testID := setupHandlerWithTask()
@@ -324,7 +694,7 @@ func TestConcurrentAccess(t *testing.T) {
 	}
 
 	// Verify all tasks were created
-	allTasks, err := h.db.GetTaskRepository().FindAll(context.Background())
+	allTasks, err := h.db.GetTaskRepository().FindAll(context.Background(), database.TaskQuery{})
 	if err != nil {
 		t.Fatalf("failed to get all tasks: %v", err)
 	}
@@ -379,3 +749,97 @@ func BenchmarkGetAll(b *testing.B) {
 		h.GetAll(w, req)
 	}
 }
+
+// TestGetAllStorageErrors tests that a busy database answers 503 with
+// Retry-After while any other failure stays a 500
+func TestGetAllStorageErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   errors.ErrorCode
+	}{
+		{"busy", fmt.Errorf("%w: no free database slot", database.ErrUnavailable), http.StatusServiceUnavailable, errors.CodeStorageBusy},
+		{"failed", stderrors.New("connection reset"), http.StatusInternalServerError, errors.CodeStorageFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := NewMockDatabase()
+			mockDB.taskRepo.err = tt.err
+			logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+				Level: slog.LevelError,
+			}))
+			h := NewTaskHandler(mockDB, logger)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			w := httptest.NewRecorder()
+
+			h.GetAll(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Retry-After") != ""; got != (tt.wantStatus == http.StatusServiceUnavailable) {
+				t.Errorf("unexpected Retry-After header %q", w.Header().Get("Retry-After"))
+			}
+
+			var apiErr errors.APIError
+			if err := json.NewDecoder(w.Body).Decode(&apiErr); err != nil {
+				t.Fatalf("failed to decode error: %v", err)
+			}
+			if apiErr.Code != tt.wantCode {
+				t.Errorf("expected code %s, got %s", tt.wantCode, apiErr.Code)
+			}
+		})
+	}
+}
+
+// brokenWriter stands in for a client that has gone away: every Write fails.
+type brokenWriter struct {
+	header  http.Header
+	headers int
+	writes  int
+}
+
+func (w *brokenWriter) Header() http.Header { return w.header }
+
+func (w *brokenWriter) WriteHeader(int) { w.headers++ }
+
+func (w *brokenWriter) Write([]byte) (int, error) {
+	w.writes++
+	return 0, stderrors.New("write: broken pipe")
+}
+
+// TestWriteFailureLogged tests that a failed response write is logged once
+// and nothing more is written to the client
+func TestWriteFailureLogged(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(h *TaskHandler) http.HandlerFunc
+		path    string
+		wantLog string
+	}{
+		{"task", func(h *TaskHandler) http.HandlerFunc { return h.GetAll }, "/api/v1/tasks", "Failed to write response"},
+		{"export", func(h *TaskHandler) http.HandlerFunc { return h.Export }, "/api/v1/tasks/export", "Export aborted: failed to write response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&logs, nil))
+			h := NewTaskHandler(NewMockDatabase(), logger)
+			h.db.GetTaskRepository().Create(context.Background(), &database.Task{ID: uuid.New(), Title: "Task"})
+
+			w := &brokenWriter{header: http.Header{}}
+			tt.handler(h)(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.writes != 1 || w.headers > 1 {
+				t.Errorf("expected a single write attempt, got %d writes and %d headers", w.writes, w.headers)
+			}
+			if !strings.Contains(logs.String(), tt.wantLog) {
+				t.Errorf("expected %q to be logged, got %s", tt.wantLog, logs.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+// Completion trend window bounds, in days.
+const (
+	defaultTrendDays = 30
+	maxTrendDays     = 366
+)
+
+type trendDay struct {
+	Date      string `json:"date"`
+	Completed int64  `json:"completed"`
+}
+
+type trendResponse struct {
+	Timezone string     `json:"timezone"`
+	Days     []trendDay `json:"days"`
+}
+
+// CompletionTrend handles GET /api/v1/tasks/completion-trend?days=30&tz=UTC:
+// how many tasks were completed on each of the last days calendar days,
+// today included, oldest first. Days are bucketed in the IANA time zone tz
+// (UTC by default), and days without completions are reported as zero.
+func (h *TaskHandler) CompletionTrend(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	days, loc, apiErr := parseTrendParams(params)
+	if apiErr != nil {
+		h.logger.Warn("Invalid completion trend query", "error", apiErr.Message, "query", r.URL.RawQuery)
+		errors.RespondWithError(w, http.StatusBadRequest, apiErr)
+		return
+	}
+
+	// Midnight is taken from the calendar, so days across a DST change still
+	// start at midnight local time
+	now := h.clock.Now().In(loc)
+	end := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
+	start := time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, loc)
+
+	counts, err := h.db.GetTaskRepository().CountCompletedByDay(r.Context(), start.Unix(), end.Unix(), loc)
+	if err != nil {
+		h.logger.Error("Failed to count completed tasks in database", "error", err)
+		h.storageFailed(w, err, "Failed to count completed tasks")
+		return
+	}
+
+	response := trendResponse{Timezone: loc.String(), Days: make([]trendDay, days)}
+	for i := range response.Days {
+		date := time.Date(start.Year(), start.Month(), start.Day()+i, 0, 0, 0, 0, loc).Format(time.DateOnly)
+		response.Days[i] = trendDay{Date: date, Completed: counts[date]}
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		h.encodingFailed(w, err)
+		return
+	}
+
+	h.write(w, http.StatusOK, data)
+}
+
+// parseTrendParams reads ?days= and ?tz=.
+func parseTrendParams(params url.Values) (int, *time.Location, *errors.APIError) {
+	days := defaultTrendDays
+	if params.Has("days") {
+		n, err := strconv.Atoi(params.Get("days"))
+		if err != nil || n < 1 || n > maxTrendDays {
+			return 0, nil, errors.NewBadRequestError("days must be a number from 1 to " + strconv.Itoa(maxTrendDays)).WithCode(errors.CodeInvalidQuery)
+		}
+		days = n
+	}
+
+	loc := time.UTC
+	if params.Has("tz") {
+		// "Local" would be the server's zone, which MongoDB does not know
+		name := params.Get("tz")
+		l, err := time.LoadLocation(name)
+		if err != nil || name == "" || name == "Local" {
+			return 0, nil, errors.NewBadRequestError("tz must be an IANA time zone such as Europe/Berlin").WithCode(errors.CodeInvalidQuery)
+		}
+		loc = l
+	}
+
+	return days, loc, nil
+}
@@ -1,11 +1,34 @@
 package handlers
 
 import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/PinceredCoder/restGo/internal/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// validationCodes maps the proto field a rule failed on to its error code.
+// Each of these fields has a single rule, so the field identifies the failure.
+var validationCodes = map[string]errors.ErrorCode{
+	"Title":      errors.CodeTitleRequired,
+	"AssigneeId": errors.CodeAssigneeInvalid,
+	"Ids":        errors.CodeLookupIDsInvalid,
+	"Updates":    errors.CodeBatchInvalid,
+	"Tasks":      errors.CodeBatchInvalid,
+	"Id":         errors.CodeInvalidTaskID,
+}
+
 func (h *TaskHandler) convertValidationError(err error) *errors.APIError {
 	errorMsg := err.Error()
 	lines := strings.Split(errorMsg, "\n")
@@ -42,8 +65,186 @@ func (h *TaskHandler) convertValidationError(err error) *errors.APIError {
 	if len(details) == 0 {
 		return errors.NewValidationError("Validation failed", map[string]string{
 			"error": errorMsg,
+		}).WithCode(errors.CodeValidationFailed)
+	}
+
+	code, ok := validationCodes[details[0].Field]
+	if !ok {
+		code = errors.CodeValidationFailed
+	}
+
+	return errors.NewValidationError("Validation failed", details).WithCode(code)
+}
+
+// rejectionStatus is the status for a rejected request body. A body that
+// parsed but whose values break the rules (VALIDATION_ERROR) is 422
+// Unprocessable Entity; one that could not be read or parsed is 400.
+func rejectionStatus(apiErr *errors.APIError) int {
+	if apiErr.Type == errors.ErrorTypeValidation {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+var (
+	// protojson errors end with "(line L:C): <reason>"; the prefix is not stable
+	unmarshalErrorPattern = regexp.MustCompile(`(syntax error )?\(line (\d+):(\d+)\): (.*)$`)
+	fieldReasonPattern    = regexp.MustCompile(`^(invalid value for \w+ field|unknown field|duplicate field) "?([\w.]+)"?`)
+)
+
+func (h *TaskHandler) convertUnmarshalError(err error) *errors.APIError {
+	apiErr := errors.NewBadRequestError("Invalid JSON format").WithCode(errors.CodeInvalidJSON)
+
+	match := unmarshalErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return apiErr
+	}
+
+	line, _ := strconv.Atoi(match[2])
+	column, _ := strconv.Atoi(match[3])
+	detail := errors.JSONErrorDetail{
+		Message: match[4],
+		Line:    line,
+		Column:  column,
+	}
+
+	if match[1] == "" {
+		if field := fieldReasonPattern.FindStringSubmatch(match[4]); field != nil {
+			detail.Field = field[2]
+			detail.Message = field[1]
+		}
+	}
+
+	apiErr.Details = detail
+	return apiErr
+}
+
+// unmarshal decodes a request body. Unknown members are rejected, and
+// reported by convertUnmarshalError, unless the handler was built
+// WithDiscardUnknown.
+func (h *TaskHandler) unmarshal(data []byte, req proto.Message) error {
+	return protojson.UnmarshalOptions{DiscardUnknown: h.discardUnknown}.Unmarshal(data, req)
+}
+
+// isEmptyBody reports whether a request body has no content besides whitespace.
+func isEmptyBody(data []byte) bool {
+	return len(bytes.TrimSpace(data)) == 0
+}
+
+// validateRequired enforces the fields configured as required: they must
+// hold more than whitespace. The proto rules still apply first, so this can
+// only make validation stricter.
+func (h *TaskHandler) validateRequired(title, description string) *errors.APIError {
+	var details []errors.ValidationErrorDetail
+
+	if slices.Contains(h.requiredFields, "title") && strings.TrimSpace(title) == "" {
+		details = append(details, errors.ValidationErrorDetail{
+			Field:   "Title",
+			Message: "value is required",
+		})
+	}
+
+	if slices.Contains(h.requiredFields, "description") && strings.TrimSpace(description) == "" {
+		details = append(details, errors.ValidationErrorDetail{
+			Field:   "Description",
+			Message: "value is required",
 		})
 	}
 
-	return errors.NewValidationError("Validation failed", details)
+	if len(details) == 0 {
+		return nil
+	}
+
+	code := errors.CodeTitleRequired
+	if details[0].Field == "Description" {
+		code = errors.CodeDescriptionRequired
+	}
+	return errors.NewValidationError("Validation failed", details).WithCode(code)
+}
+
+// validateExpiry checks a requested expiry, which must be in the future, and
+// converts it for storage in whole seconds like the other timestamps. No
+// expiry gives nil.
+func (h *TaskHandler) validateExpiry(expiresAt *timestamppb.Timestamp) (*time.Time, *errors.APIError) {
+	if expiresAt == nil {
+		return nil, nil
+	}
+
+	t := expiresAt.AsTime().Truncate(time.Second)
+	if !t.After(h.clock.Now()) {
+		return nil, errors.NewValidationError("Validation failed", []errors.ValidationErrorDetail{{
+			Field:   "ExpiresAt",
+			Message: "value must be in the future",
+		}}).WithCode(errors.CodeExpiryInvalid)
+	}
+	return &t, nil
+}
+
+// validateLengths enforces the configured maximum title and description
+// lengths. Lengths are counted in runes, like the proto rules.
+func (h *TaskHandler) validateLengths(title, description string) *errors.APIError {
+	var details []errors.ValidationErrorDetail
+
+	if utf8.RuneCountInString(title) > h.maxTitleLen {
+		details = append(details, errors.ValidationErrorDetail{
+			Field:   "Title",
+			Message: fmt.Sprintf("value length must be at most %d runes", h.maxTitleLen),
+		})
+	}
+
+	if utf8.RuneCountInString(description) > h.maxDescriptionLen {
+		details = append(details, errors.ValidationErrorDetail{
+			Field:   "Description",
+			Message: fmt.Sprintf("value length must be at most %d runes", h.maxDescriptionLen),
+		})
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+
+	// When both fields are too long the code names the first, the title
+	code := errors.CodeTitleTooLong
+	if details[0].Field == "Description" {
+		code = errors.CodeDescriptionTooLong
+	}
+	return errors.NewValidationError("Validation failed", details).WithCode(code)
+}
+
+// validateCharacters rejects control characters such as NUL or ESC, which
+// break log processing and terminals downstream. Descriptions may still
+// contain tabs and line breaks. Input is rejected rather than silently
+// stripped, so what the client sent is exactly what gets stored.
+func (h *TaskHandler) validateCharacters(title, description string) *errors.APIError {
+	var details []errors.ValidationErrorDetail
+
+	if r, ok := findControlCharacter(title, ""); ok {
+		details = append(details, errors.ValidationErrorDetail{
+			Field:   "Title",
+			Message: fmt.Sprintf("value must not contain control character %U", r),
+		})
+	}
+
+	if r, ok := findControlCharacter(description, "\t\n\r"); ok {
+		details = append(details, errors.ValidationErrorDetail{
+			Field:   "Description",
+			Message: fmt.Sprintf("value must not contain control character %U", r),
+		})
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+	return errors.NewValidationError("Validation failed", details).WithCode(errors.CodeControlCharacters)
+}
+
+// findControlCharacter returns the first control character in s that is not
+// listed in allowed.
+func findControlCharacter(s, allowed string) (rune, bool) {
+	for _, r := range s {
+		if unicode.IsControl(r) && !strings.ContainsRune(allowed, r) {
+			return r, true
+		}
+	}
+	return 0, false
 }
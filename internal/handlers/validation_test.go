@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+// TestValidateCharacters tests which control characters titles and descriptions may contain
+func TestValidateCharacters(t *testing.T) {
+	h := setupHandler()
+
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		wantFields  []string
+	}{
+		{"plain text", "Buy milk", "Two litres", nil},
+		{"unicode text", "Café ☕", "日本語", nil},
+		{"description whitespace", "Title", "line one\nline two\r\n\tindented", nil},
+		{"null byte in title", "Buy\x00milk", "", []string{"Title"}},
+		{"newline in title", "Buy\nmilk", "", []string{"Title"}},
+		{"escape in description", "Title", "\x1b[31mred", []string{"Description"}},
+		{"delete in description", "Title", "oops\x7f", []string{"Description"}},
+		{"C1 control", "Title\u0085", "", []string{"Title"}},
+		{"both fields", "\x07", "\x00", []string{"Title", "Description"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := h.validateCharacters(tt.title, tt.description)
+
+			if tt.wantFields == nil {
+				if apiErr != nil {
+					t.Errorf("expected no error, got %+v", apiErr.Details)
+				}
+				return
+			}
+
+			if apiErr == nil {
+				t.Fatal("expected a validation error")
+			}
+
+			if apiErr.Code != errors.CodeControlCharacters {
+				t.Errorf("expected code %s, got %s", errors.CodeControlCharacters, apiErr.Code)
+			}
+
+			details := apiErr.Details.([]errors.ValidationErrorDetail)
+			if len(details) != len(tt.wantFields) {
+				t.Fatalf("expected %d details, got %+v", len(tt.wantFields), details)
+			}
+			for i, field := range tt.wantFields {
+				if details[i].Field != field {
+					t.Errorf("detail %d: expected field %s, got %s", i, field, details[i].Field)
+				}
+			}
+		})
+	}
+}
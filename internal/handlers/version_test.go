@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestVersion tests that build info is reported as JSON
+func TestVersion(t *testing.T) {
+	info := BuildInfo{Version: "v1.2.3", Commit: "abc1234", BuildTime: "2025-11-13T10:00:00Z"}
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	w := httptest.NewRecorder()
+
+	Version(info)(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+
+	var got BuildInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if got != info {
+		t.Errorf("expected %+v, got %+v", info, got)
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+// StrictAccept answers 406 when the request's Accept header rules out every
+// media type in produces. Wildcards and the versioned vendor types that
+// APIVersioning understands always count as acceptable, and so does a
+// request without an Accept header.
+func StrictAccept(produces ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept := r.Header.Values("Accept")
+			if len(accept) == 0 || acceptsAny(accept, produces) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			errors.RespondWithError(w, http.StatusNotAcceptable,
+				errors.NewNotAcceptableError("Accept must allow one of: "+strings.Join(produces, ", ")).WithCode(errors.CodeNotAcceptable))
+		})
+	}
+}
+
+// acceptsAny reports whether a media range with a non-zero quality covers one
+// of produces.
+func acceptsAny(accept []string, produces []string) bool {
+	for _, header := range accept {
+		for _, mediaRange := range strings.Split(header, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q <= 0 {
+				continue
+			}
+
+			switch {
+			case mediaType == "*/*", mediaType == "application/*":
+				return true
+			case vendorMediaType.MatchString(mediaType):
+				return true
+			case slices.Contains(produces, mediaType):
+				return true
+			}
+		}
+	}
+	return false
+}
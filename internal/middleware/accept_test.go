@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestStrictAccept tests which Accept headers pass strict negotiation
+func TestStrictAccept(t *testing.T) {
+	tests := []struct {
+		name       string
+		accept     string
+		wantStatus int
+	}{
+		{"no Accept header", "", http.StatusOK},
+		{"plain JSON", "application/json", http.StatusOK},
+		{"JSON with parameters", "application/json; charset=utf-8", http.StatusOK},
+		{"wildcard", "*/*", http.StatusOK},
+		{"application wildcard", "application/*", http.StatusOK},
+		{"vendor type", "application/vnd.restgo.v1+json", http.StatusOK},
+		{"HTML with JSON fallback", "text/html, application/json;q=0.5", http.StatusOK},
+		{"NDJSON", "application/x-ndjson", http.StatusOK},
+		{"HTML only", "text/html", http.StatusNotAcceptable},
+		{"XML only", "application/xml", http.StatusNotAcceptable},
+		{"JSON refused", "application/json;q=0, text/html", http.StatusNotAcceptable},
+	}
+
+	handler := StrictAccept("application/json", "application/x-ndjson")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
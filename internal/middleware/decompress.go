@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+// decoders are the request content encodings Decompress knows how to undo.
+var decoders = map[string]func(io.Reader) (io.ReadCloser, error){
+	"gzip": func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+	// HTTP's "deflate" is zlib-wrapped, not raw DEFLATE
+	"deflate": zlib.NewReader,
+}
+
+// Decompress transparently decodes request bodies sent with one of the
+// allowed Content-Encodings, so handlers always read plain bytes. At most
+// limit decoded bytes are read; beyond that the body read fails, which
+// handlers report as an unreadable body. Any other encoding is answered with
+// 415 and an Accept-Encoding header naming the allowed ones.
+func Decompress(allowed []string, limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+			if encoding == "" || encoding == "identity" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			decoder, ok := decoders[encoding]
+			if !ok || !slices.Contains(allowed, encoding) {
+				accepted := strings.Join(append([]string{"identity"}, allowed...), ", ")
+				w.Header().Set("Accept-Encoding", accepted)
+				errors.RespondWithError(w, http.StatusUnsupportedMediaType,
+					errors.NewUnsupportedMediaTypeError("Content-Encoding must be one of: "+accepted).WithCode(errors.CodeUnsupportedEncoding))
+				return
+			}
+
+			body, err := decoder(r.Body)
+			if err != nil {
+				errors.RespondWithError(w, http.StatusBadRequest,
+					errors.NewBadRequestError("Request body is not valid "+encoding).WithCode(errors.CodeUnreadableBody))
+				return
+			}
+			defer body.Close()
+
+			r.Body = http.MaxBytesReader(w, body, limit)
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+			r.ContentLength = -1
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func compress(t *testing.T, encoding, body string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	if encoding == "gzip" {
+		w = gzip.NewWriter(&buf)
+	} else {
+		w = zlib.NewWriter(&buf)
+	}
+	w.Write([]byte(body))
+	w.Close()
+	return buf.Bytes()
+}
+
+// TestDecompress tests decoding of allowed encodings and rejection of others
+func TestDecompress(t *testing.T) {
+	body := `{"title":"Compressed"}`
+
+	tests := []struct {
+		name       string
+		encoding   string
+		body       []byte
+		limit      int64
+		wantStatus int
+		wantBody   string
+	}{
+		{"plain", "", []byte(body), 1024, http.StatusOK, body},
+		{"identity", "identity", []byte(body), 1024, http.StatusOK, body},
+		{"gzip", "gzip", compress(t, "gzip", body), 1024, http.StatusOK, body},
+		{"gzip is case-insensitive", "GZIP", compress(t, "gzip", body), 1024, http.StatusOK, body},
+		{"not allowed", "deflate", compress(t, "deflate", body), 1024, http.StatusUnsupportedMediaType, ""},
+		{"unknown", "br", []byte(body), 1024, http.StatusUnsupportedMediaType, ""},
+		{"corrupt gzip", "gzip", []byte(body), 1024, http.StatusBadRequest, ""},
+		{"over the limit", "gzip", compress(t, "gzip", strings.Repeat("a", 4096)), 1024, http.StatusRequestEntityTooLarge, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := Decompress([]string{"gzip"}, tt.limit)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, err := io.ReadAll(r.Body)
+				if err != nil {
+					w.WriteHeader(http.StatusRequestEntityTooLarge)
+					return
+				}
+				if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+					t.Error("expected Content-Encoding to be removed once decoded")
+				}
+				got = string(data)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewReader(tt.body))
+			if tt.encoding != "" {
+				req.Header.Set("Content-Encoding", tt.encoding)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if got != tt.wantBody {
+				t.Errorf("expected body %q, got %q", tt.wantBody, got)
+			}
+			if w.Code == http.StatusUnsupportedMediaType && w.Header().Get("Accept-Encoding") != "identity, gzip" {
+				t.Errorf("expected Accept-Encoding to list the allowed encodings, got %q", w.Header().Get("Accept-Encoding"))
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// Deprecated marks the routes in routes as deprecated. They are keyed by
+// method and chi route pattern, such as "PUT /api/v1/tasks/{id}", and map to
+// the date the route is to be removed, or the zero time when none is set.
+// Responses to them carry a Deprecation header, and a Sunset header with the
+// removal date, and every use is logged with a running count so the remaining
+// callers can be found. Other routes pass through untouched.
+func Deprecated(logger *slog.Logger, routes map[string]time.Time) func(http.Handler) http.Handler {
+	uses := make(map[string]*atomic.Int64, len(routes))
+	for route := range routes {
+		uses[route] = new(atomic.Int64)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := r.Method + " " + findRoute(r)
+			sunset, ok := routes[route]
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Set before the handler runs, which may write the response at once
+			w.Header().Set("Deprecation", "true")
+			if !sunset.IsZero() {
+				w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+
+			logger.Info("Deprecated route used",
+				"route", route,
+				"uses", uses[route].Add(1),
+				"request_id", chimiddleware.GetReqID(r.Context()),
+			)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// findRoute looks up the route pattern the request will be routed to, in the
+// form RoutePattern reports once routing has finished. It is empty when
+// nothing matches.
+func findRoute(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return ""
+	}
+
+	path := rctx.RoutePath
+	if path == "" {
+		path = r.URL.Path
+	}
+
+	pattern := rctx.Routes.Find(chi.NewRouteContext(), r.Method, path)
+	if pattern != "/" {
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	return pattern
+}
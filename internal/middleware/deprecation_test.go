@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestDeprecated tests that only flagged routes get the deprecation headers
+func TestDeprecated(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+	routes := map[string]time.Time{
+		"PUT /api/v1/tasks/{id}":   time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		"POST /api/v1/tasks/batch": {},
+	}
+
+	r := chi.NewRouter()
+	r.Use(Deprecated(logger, routes))
+	r.Route("/api/v1/tasks", func(r chi.Router) {
+		ok := func(w http.ResponseWriter, r *http.Request) {}
+		r.Post("/batch", ok)
+		r.Get("/{id}", ok)
+		r.Put("/{id}", ok)
+	})
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		wantDeprecation string
+		wantSunset      string
+	}{
+		{"deprecated with sunset", http.MethodPut, "/api/v1/tasks/abc", "true", "Thu, 31 Dec 2026 00:00:00 GMT"},
+		{"deprecated without sunset", http.MethodPost, "/api/v1/tasks/batch", "true", ""},
+		{"other method on the route", http.MethodGet, "/api/v1/tasks/abc", "", ""},
+		{"unrouted", http.MethodPut, "/api/v1/other", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Deprecation"); got != tt.wantDeprecation {
+				t.Errorf("expected Deprecation %q, got %q", tt.wantDeprecation, got)
+			}
+			if got := w.Header().Get("Sunset"); got != tt.wantSunset {
+				t.Errorf("expected Sunset %q, got %q", tt.wantSunset, got)
+			}
+		})
+	}
+}
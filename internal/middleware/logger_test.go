@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestRequestLogger tests that quiet routes log below Info and others at Info
+func TestRequestLogger(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	r := chi.NewRouter()
+	r.Use(RequestLogger(logger, []string{"/health"}))
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {})
+	r.Get("/api/v1/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for range 3 {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	}
+	if logs.Len() != 0 {
+		t.Fatalf("expected no Info lines for health probes, got %s", logs.String())
+	}
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/tasks/42", nil))
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one log line, got %d: %s", len(lines), logs.String())
+	}
+
+	var entry struct {
+		Level  string `json:"level"`
+		Route  string `json:"route"`
+		Path   string `json:"path"`
+		Status int    `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("failed to unmarshal log line: %v", err)
+	}
+	if entry.Level != "INFO" || entry.Route != "/api/v1/tasks/{id}" || entry.Path != "/api/v1/tasks/42" || entry.Status != http.StatusNotFound {
+		t.Errorf("unexpected log entry %+v", entry)
+	}
+
+	// Debug logging brings the quiet routes back
+	logs.Reset()
+	logger = slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	r = chi.NewRouter()
+	r.Use(RequestLogger(logger, []string{"/health"}))
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	if !strings.Contains(logs.String(), `"level":"DEBUG"`) {
+		t.Errorf("expected a Debug line for the health probe, got %s", logs.String())
+	}
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// PrettyJSON indents JSON responses, errors included, for requests with
+// ?pretty=true. Output is compact otherwise. Only application/json bodies are
+// buffered; anything else, such as an NDJSON export, streams through as
+// written.
+func PrettyJSON(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if pretty, _ := strconv.ParseBool(r.URL.Query().Get("pretty")); !pretty {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		pw := &prettyWriter{ResponseWriter: w}
+		next.ServeHTTP(pw, r)
+		pw.finish()
+	})
+}
+
+// prettyWriter holds back a JSON body until the handler returns, then writes
+// it indented.
+type prettyWriter struct {
+	http.ResponseWriter
+	status    int
+	decided   bool
+	buffering bool
+	body      bytes.Buffer
+}
+
+func (pw *prettyWriter) decide() {
+	if pw.decided {
+		return
+	}
+	pw.decided = true
+
+	mediaType, _, _ := mime.ParseMediaType(pw.Header().Get("Content-Type"))
+	pw.buffering = mediaType == "application/json"
+}
+
+func (pw *prettyWriter) WriteHeader(status int) {
+	pw.decide()
+	if !pw.buffering {
+		pw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if pw.status == 0 {
+		pw.status = status
+	}
+}
+
+func (pw *prettyWriter) Write(data []byte) (int, error) {
+	pw.decide()
+	if !pw.buffering {
+		return pw.ResponseWriter.Write(data)
+	}
+	if pw.status == 0 {
+		pw.status = http.StatusOK
+	}
+	return pw.body.Write(data)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (pw *prettyWriter) Unwrap() http.ResponseWriter {
+	return pw.ResponseWriter
+}
+
+// Flush passes through for streamed responses; a buffered body is only
+// written once the handler is done.
+func (pw *prettyWriter) Flush() {
+	if pw.buffering {
+		return
+	}
+	if flusher, ok := pw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (pw *prettyWriter) finish() {
+	if !pw.buffering {
+		return
+	}
+
+	data := pw.body.Bytes()
+	var indented bytes.Buffer
+	if len(data) > 0 && json.Indent(&indented, bytes.TrimSpace(data), "", "  ") == nil {
+		indented.WriteByte('\n')
+		data = indented.Bytes()
+		pw.Header().Del("Content-Length")
+	}
+
+	pw.ResponseWriter.WriteHeader(pw.status)
+	pw.ResponseWriter.Write(data)
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+// TestPrettyJSON tests that ?pretty=true indents JSON bodies, errors included
+func TestPrettyJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		handler  http.HandlerFunc
+		wantBody string
+	}{
+		{
+			name:   "compact by default",
+			target: "/api/v1/tasks",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"tasks":[{"id":"1"}]}`))
+			},
+			wantBody: `{"tasks":[{"id":"1"}]}`,
+		},
+		{
+			name:   "indented when requested",
+			target: "/api/v1/tasks?pretty=true",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"tasks":[{"id":"1"}]}`))
+			},
+			wantBody: "{\n  \"tasks\": [\n    {\n      \"id\": \"1\"\n    }\n  ]\n}\n",
+		},
+		{
+			name:   "errors are indented",
+			target: "/api/v1/tasks/x?pretty=1",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				errors.RespondWithError(w, http.StatusBadRequest,
+					errors.NewBadRequestError("Invalid task ID format").WithCode(errors.CodeInvalidTaskID))
+			},
+			wantBody: "{\n  \"type\": \"BAD_REQUEST\",\n  \"code\": \"TASK_ID_INVALID\",\n  \"message\": \"Invalid task ID format\"\n}\n",
+		},
+		{
+			name:   "other content types pass through",
+			target: "/api/v1/tasks/export?pretty=true",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				w.Write([]byte("{\"id\":\"1\"}\n"))
+			},
+			wantBody: "{\"id\":\"1\"}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+
+			PrettyJSON(tt.handler).ServeHTTP(w, req)
+
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("expected body %q, got %q", tt.wantBody, got)
+			}
+		})
+	}
+}
+
+// TestPrettyJSONKeepsStatus tests that buffering does not lose the status code
+func TestPrettyJSONKeepsStatus(t *testing.T) {
+	handler := PrettyJSON(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks?pretty=true", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d", w.Code)
+	}
+}
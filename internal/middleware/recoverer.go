@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// Recoverer turns handler panics into a JSON 500 response. The panic value and
+// stack trace go to the structured log only; clients never see them.
+func Recoverer(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+
+				// The standard way to abort a response; let net/http handle it
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				logger.Error("Panic recovered while handling request",
+					"panic", fmt.Sprint(rec),
+					"stack", string(debug.Stack()),
+					"request_id", chimiddleware.GetReqID(r.Context()),
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+
+				errors.RespondWithError(w, http.StatusInternalServerError,
+					errors.NewInternalError("Internal server error").WithCode(errors.CodeUnexpected))
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
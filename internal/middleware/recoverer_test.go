@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// TestRecoverer tests that a panic becomes a JSON 500 and a structured log entry
+func TestRecoverer(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("something broke")
+	})
+	handler := chimiddleware.RequestID(Recoverer(logger)(panicking))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+
+	var apiErr errors.APIError
+	if err := json.Unmarshal(w.Body.Bytes(), &apiErr); err != nil {
+		t.Fatalf("failed to unmarshal error: %v", err)
+	}
+
+	if apiErr.Type != errors.ErrorTypeInternal {
+		t.Errorf("expected error type %s, got %s", errors.ErrorTypeInternal, apiErr.Type)
+	}
+
+	if strings.Contains(w.Body.String(), "something broke") || strings.Contains(w.Body.String(), "goroutine") {
+		t.Errorf("response leaked panic details: %s", w.Body.String())
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON log entry, got %q: %v", logs.String(), err)
+	}
+
+	if entry["level"] != "ERROR" || entry["panic"] != "something broke" {
+		t.Errorf("unexpected log entry: %v", entry)
+	}
+
+	if stack, _ := entry["stack"].(string); !strings.Contains(stack, "goroutine") {
+		t.Error("expected stack trace in log entry")
+	}
+
+	if requestID, _ := entry["request_id"].(string); requestID == "" {
+		t.Error("expected request ID in log entry")
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+)
+
+// Tenant stores the tenant named in header in the request context, where a
+// sharded database uses it to pick the tenant's shard. Requests without the
+// header go to the default database. A tenant already in the context, set
+// by whatever authenticated the request, is kept and the header ignored.
+//
+// The header is trusted as sent; put this behind whatever authenticates the
+// tenant.
+func Tenant(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := database.TenantFromContext(r.Context()); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if tenant := r.Header.Get(header); tenant != "" {
+				r = r.WithContext(database.WithTenant(r.Context(), tenant))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PinceredCoder/restGo/internal/database"
+)
+
+// TestTenant tests copying the tenant header into the request context
+func TestTenant(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		authTenant string
+		wantTenant string
+		wantOK     bool
+	}{
+		{"tenant header", "acme", "", "acme", true},
+		{"no header", "", "", "", false},
+		{"authenticated tenant wins", "globex", "acme", "acme", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTenant string
+			var gotOK bool
+			handler := Tenant("X-Tenant-ID")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotTenant, gotOK = database.TenantFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			if tt.authTenant != "" {
+				req = req.WithContext(database.WithTenant(req.Context(), tt.authTenant))
+			}
+			if tt.header != "" {
+				req.Header.Set("X-Tenant-ID", tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if gotTenant != tt.wantTenant || gotOK != tt.wantOK {
+				t.Errorf("expected tenant %q (%v), got %q (%v)", tt.wantTenant, tt.wantOK, gotTenant, gotOK)
+			}
+		})
+	}
+}
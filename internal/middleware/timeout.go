@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+// Timeout cancels the request context after d and answers 503 with a JSON
+// APIError if the handler has not finished by then. Anything the handler
+// writes after the deadline is discarded. A zero d disables the timeout.
+//
+// The handler's response is buffered until it returns, so this must not wrap
+// streaming endpoints.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	body, _ := json.Marshal(errors.NewTimeoutError("Request timed out").WithCode(errors.CodeRequestTimeout))
+
+	return func(next http.Handler) http.Handler {
+		if d <= 0 {
+			return next
+		}
+
+		timeoutHandler := http.TimeoutHandler(next, d, string(body))
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Only survives when the timeout fires; handler headers replace it otherwise
+			w.Header().Set("Content-Type", "application/json")
+			timeoutHandler.ServeHTTP(w, r)
+		})
+	}
+}
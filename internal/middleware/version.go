@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/PinceredCoder/restGo/internal/errors"
+)
+
+// DefaultAPIVersion is used when the client does not ask for a specific version.
+const DefaultAPIVersion = 1
+
+var vendorMediaType = regexp.MustCompile(`^application/vnd\.restgo\.v(\d+)\+json$`)
+
+type apiVersionKey struct{}
+
+// APIVersion returns the schema version negotiated for the request.
+func APIVersion(ctx context.Context) int {
+	if version, ok := ctx.Value(apiVersionKey{}).(int); ok {
+		return version
+	}
+	return DefaultAPIVersion
+}
+
+// APIVersioning selects the task schema version from an Accept header such as
+// application/vnd.restgo.v1+json and stores it for APIVersion. Requests without
+// a vendor media type get DefaultAPIVersion. If the client only accepts
+// versions outside supported, it receives 406.
+func APIVersioning(supported ...int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			version, ok := negotiateVersion(r.Header.Values("Accept"), supported)
+			if !ok {
+				errors.RespondWithError(w, http.StatusNotAcceptable,
+					errors.NewNotAcceptableError(fmt.Sprintf("Unsupported API version; supported versions: %v", supported)).WithCode(errors.CodeUnsupportedAPIVersion))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), apiVersionKey{}, version)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// negotiateVersion picks the highest supported version the client asked for.
+// Any non-vendor media range (application/json, */*) also accepts the default.
+func negotiateVersion(accept []string, supported []int) (int, bool) {
+	best := 0
+	vendorOnly := true
+	requested := false
+
+	for _, header := range accept {
+		for _, mediaRange := range strings.Split(header, ",") {
+			mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+
+			match := vendorMediaType.FindStringSubmatch(mediaType)
+			if match == nil {
+				vendorOnly = false
+				continue
+			}
+
+			requested = true
+			version, _ := strconv.Atoi(match[1])
+			if slices.Contains(supported, version) && version > best {
+				best = version
+			}
+		}
+	}
+
+	switch {
+	case best > 0:
+		return best, true
+	case requested && vendorOnly:
+		return 0, false
+	default:
+		return DefaultAPIVersion, true
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestAPIVersioning tests version selection from the Accept header
+func TestAPIVersioning(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		wantStatus  int
+		wantVersion int
+	}{
+		{"no Accept header", "", http.StatusOK, 1},
+		{"plain JSON", "application/json", http.StatusOK, 1},
+		{"explicit v1", "application/vnd.restgo.v1+json", http.StatusOK, 1},
+		{"highest supported wins", "application/vnd.restgo.v1+json, application/vnd.restgo.v2+json;q=0.9", http.StatusOK, 2},
+		{"unknown version", "application/vnd.restgo.v9+json", http.StatusNotAcceptable, 0},
+		{"unknown version with fallback", "application/vnd.restgo.v9+json, */*;q=0.1", http.StatusOK, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotVersion int
+			handler := APIVersioning(1, 2)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotVersion = APIVersion(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			if gotVersion != tt.wantVersion {
+				t.Errorf("expected version %d, got %d", tt.wantVersion, gotVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/PinceredCoder/restGo/internal/config"
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/events"
+	"github.com/PinceredCoder/restGo/internal/handlers"
+	"github.com/PinceredCoder/restGo/internal/middleware"
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// NewRouter builds the complete HTTP handler: middleware, the task API and
+// the health and metadata endpoints. It only wires routes; connecting db is
+// the caller's job. healthHandler comes from the caller so it can take the
+// instance out of rotation before shutting down.
+//
+// middlewares, such as an embedder's authentication or tracing, wrap the
+// /api/v1 routes only, in the order given. They run after the server-wide
+// middleware (request ID, logging, panic recovery, decompression and
+// deprecation handling) and before the tenant header is read and the API's
+// own Accept and version negotiation, so they may authenticate or answer a
+// request themselves without it reaching the API. One that sets the tenant
+// with database.WithTenant, say from a verified token, takes precedence over
+// the header.
+func NewRouter(db database.Database, logger *slog.Logger, cfg *config.Config, info handlers.BuildInfo, healthHandler *handlers.HealthHandler, middlewares ...func(http.Handler) http.Handler) http.Handler {
+	r := chi.NewRouter()
+
+	r.NotFound(handlers.NotFound)
+	r.MethodNotAllowed(handlers.MethodNotAllowed)
+
+	// Strip rather than redirect: a 301 would make clients drop POST/PUT bodies
+	r.Use(chimiddleware.StripSlashes)
+	r.Use(chimiddleware.RequestID)
+	r.Use(middleware.RequestLogger(logger, cfg.QuietRoutes))
+	if len(cfg.DeprecatedRoutes) > 0 {
+		r.Use(middleware.Deprecated(logger, cfg.DeprecatedRoutes))
+	}
+	r.Use(middleware.Recoverer(logger))
+	r.Use(middleware.PrettyJSON)
+	r.Use(middleware.Decompress(cfg.RequestEncodings, int64(cfg.MaxDecompressedBytes)))
+
+	// Load has validated it
+	defaultSort, _ := database.ParseSort(cfg.DefaultSort)
+
+	notifier := events.NewChangeNotifier()
+	taskOptions := []handlers.TaskHandlerOption{
+		handlers.WithDefaultCompleted(cfg.DefaultCompleted()),
+		handlers.WithEventPublisher(events.MultiPublisher{events.NewLogPublisher(logger), notifier}),
+		handlers.WithChangeNotifier(notifier),
+		handlers.WithFieldLimits(cfg.MaxTitleLen, cfg.MaxDescriptionLen),
+		handlers.WithRequiredFields(cfg.RequiredFields),
+		handlers.WithDiscardUnknown(cfg.DiscardUnknownFields),
+		handlers.WithEmitUnset(cfg.EmitUnsetFields),
+		handlers.WithMaxResults(cfg.MaxResults),
+		handlers.WithDefaultSort(defaultSort),
+		handlers.WithMaxResponseBytes(cfg.MaxResponseBytes),
+	}
+	if cfg.SeedEnabled() {
+		taskOptions = append(taskOptions, handlers.WithSeedToken(cfg.AdminToken))
+	}
+	taskHandler := handlers.NewTaskHandler(db, logger, taskOptions...)
+
+	// Disabled methods keep a route that answers 405, so the path never looks missing
+	enabled := func(method string, h http.HandlerFunc) http.HandlerFunc {
+		// HEAD runs the GET handler, so it follows GET
+		if method == http.MethodHead {
+			method = http.MethodGet
+		}
+		if !cfg.MethodEnabled(method) {
+			return handlers.MethodNotAllowed
+		}
+		return h
+	}
+
+	// Every type the task API answers with
+	produces := []string{"application/json", "application/x-ndjson", "application/schema+json", "application/x-protobuf"}
+	apiVersions := []int{1}
+
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(middlewares...)
+		// After the embedder's middleware, so authentication comes before the
+		// header can pick a shard
+		if len(cfg.TenantShards) > 0 {
+			r.Use(middleware.Tenant(cfg.TenantHeader))
+		}
+		if cfg.StrictAccept {
+			r.Use(middleware.StrictAccept(produces...))
+		}
+		r.Use(middleware.APIVersioning(apiVersions...))
+
+		r.Get("/capabilities", handlers.Capabilities(handlers.ServerCapabilities{
+			APIVersions:          apiVersions,
+			Methods:              cfg.EnabledMethods,
+			Features:             cfg.Features,
+			RequiredFields:       cfg.RequiredFields,
+			DiscardUnknownFields: cfg.DiscardUnknownFields,
+			EmitUnsetFields:      cfg.EmitUnsetFields,
+			RequestEncodings:     cfg.RequestEncodings,
+			Limits: handlers.ServerLimits{
+				MaxTitleLength:       cfg.MaxTitleLen,
+				MaxDescriptionLength: cfg.MaxDescriptionLen,
+				MaxResults:           cfg.MaxResults,
+				MaxResponseBytes:     cfg.MaxResponseBytes,
+			},
+			ContentTypes: handlers.ContentTypes{
+				Request:  []string{"application/json", "application/json-patch+json"},
+				Response: produces,
+			},
+		}))
+
+		// Exports stream until the cursor is drained, so the request timeout does not apply
+		if cfg.FeatureEnabled("export") {
+			r.Get("/tasks/export", enabled(http.MethodGet, taskHandler.Export))
+		} else {
+			r.HandleFunc("/tasks/export", handlers.NotFound)
+		}
+
+		// Answers 403 unless SeedEnabled; see config
+		r.With(middleware.Timeout(cfg.RequestTimeout)).Post("/admin/seed", enabled(http.MethodPost, taskHandler.Seed))
+
+		if cfg.FeatureEnabled("sync") {
+			r.With(middleware.Timeout(cfg.RequestTimeout)).Post("/sync", enabled(http.MethodPost, taskHandler.Sync))
+		}
+
+		r.With(middleware.Timeout(cfg.RequestTimeout)).Route("/tasks", func(r chi.Router) {
+			handle := func(method, pattern string, h http.HandlerFunc) {
+				r.Method(method, pattern, enabled(method, h))
+			}
+			// Disabled features are not routed. Their fixed paths answer 404
+			// explicitly, or they would be taken for a task ID by /{id}
+			feature := func(name string, register func(), paths ...string) {
+				if cfg.FeatureEnabled(name) {
+					register()
+					return
+				}
+				for _, path := range paths {
+					r.HandleFunc(path, handlers.NotFound)
+				}
+			}
+
+			handle(http.MethodGet, "/", taskHandler.GetAll)
+			handle(http.MethodHead, "/", handlers.Head(taskHandler.GetAll))
+			handle(http.MethodPost, "/", taskHandler.Create)
+			feature("lookup", func() {
+				handle(http.MethodPost, "/lookup", taskHandler.Lookup)
+			}, "/lookup")
+			feature("batch", func() {
+				handle(http.MethodPost, "/batch", taskHandler.CreateBatch)
+				handle(http.MethodPatch, "/batch", taskHandler.PatchBatch)
+			}, "/batch")
+			handle(http.MethodGet, "/schema", taskHandler.Schema)
+			feature("count-by", func() {
+				handle(http.MethodGet, "/count-by", taskHandler.CountBy)
+			}, "/count-by")
+			feature("completion-trend", func() {
+				handle(http.MethodGet, "/completion-trend", taskHandler.CompletionTrend)
+			}, "/completion-trend")
+			feature("descriptor", func() {
+				handle(http.MethodGet, "/descriptor", handlers.Descriptor())
+			}, "/descriptor")
+			handle(http.MethodGet, "/{id}", taskHandler.GetByID)
+			handle(http.MethodHead, "/{id}", handlers.Head(taskHandler.GetByID))
+			handle(http.MethodPut, "/{id}", taskHandler.Update)
+			handle(http.MethodPatch, "/{id}", taskHandler.Patch)
+			handle(http.MethodDelete, "/{id}", taskHandler.Delete)
+			feature("exists", func() {
+				handle(http.MethodGet, "/{id}/exists", taskHandler.Exists)
+			})
+			feature("navigation", func() {
+				handle(http.MethodGet, "/{id}/next", taskHandler.Next)
+				handle(http.MethodGet, "/{id}/prev", taskHandler.Prev)
+			})
+			handle(http.MethodGet, "/{id}/blockers", taskHandler.Blockers)
+			handle(http.MethodPost, "/{id}/assign", taskHandler.Assign)
+			handle(http.MethodPost, "/{id}/unassign", taskHandler.Unassign)
+			handle(http.MethodPost, "/{id}/archive", taskHandler.Archive)
+			handle(http.MethodPost, "/{id}/unarchive", taskHandler.Unarchive)
+			handle(http.MethodPost, "/{id}/complete", taskHandler.Complete)
+			handle(http.MethodPost, "/{id}/reopen", taskHandler.Reopen)
+		})
+	})
+
+	r.Get("/health", healthHandler.Health)
+	r.Get("/ready", healthHandler.Ready)
+	r.Get("/metrics/cache", handlers.CacheStats(cacheStatsSource(db)))
+	r.Get("/version", handlers.Version(info))
+
+	return r
+}
+
+// noCache reports a disabled cache for databases without one.
+type noCache struct{}
+
+func (noCache) CacheStats() (database.CacheStats, bool) {
+	return database.CacheStats{}, false
+}
+
+func cacheStatsSource(db database.Database) handlers.CacheStatsSource {
+	if source, ok := db.(handlers.CacheStatsSource); ok {
+		return source
+	}
+	return noCache{}
+}
@@ -0,0 +1,350 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/PinceredCoder/restGo/internal/config"
+	"github.com/PinceredCoder/restGo/internal/database"
+	"github.com/PinceredCoder/restGo/internal/handlers"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// stubDatabase implements database.Database; routing tests never reach it
+type stubDatabase struct{}
+
+func (stubDatabase) Ping(ctx context.Context) error             { return nil }
+func (stubDatabase) Disconnect(ctx context.Context) error       { return nil }
+func (stubDatabase) GetTaskRepository() database.TaskRepository { return nil }
+
+func setupRouter(methods ...string) http.Handler {
+	return setupRouterWithFeatures(methods, config.SupportedFeatures)
+}
+
+func setupRouterWithFeatures(methods, features []string, middlewares ...func(http.Handler) http.Handler) http.Handler {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	cfg := &config.Config{
+		EnabledMethods:    methods,
+		Features:          features,
+		MaxTitleLen:       100,
+		MaxDescriptionLen: 500,
+	}
+	return NewRouter(stubDatabase{}, logger, cfg, handlers.BuildInfo{Version: "test"}, handlers.NewHealthHandler(stubDatabase{}, logger), middlewares...)
+}
+
+// TestNewRouterRoutes tests that every endpoint is registered
+func TestNewRouterRoutes(t *testing.T) {
+	router := setupRouter(config.SupportedMethods...)
+
+	var got []string
+	err := chi.Walk(router.(chi.Routes), func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		got = append(got, method+" "+route)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("chi.Walk() returned error: %v", err)
+	}
+
+	want := []string{
+		"GET /health",
+		"GET /ready",
+		"GET /metrics/cache",
+		"GET /version",
+		"GET /api/v1/capabilities",
+		"POST /api/v1/admin/seed",
+		"POST /api/v1/sync",
+		"GET /api/v1/tasks/",
+		"HEAD /api/v1/tasks/",
+		"POST /api/v1/tasks/",
+		"POST /api/v1/tasks/lookup",
+		"POST /api/v1/tasks/batch",
+		"PATCH /api/v1/tasks/batch",
+		"GET /api/v1/tasks/export",
+		"GET /api/v1/tasks/schema",
+		"GET /api/v1/tasks/count-by",
+		"GET /api/v1/tasks/completion-trend",
+		"GET /api/v1/tasks/descriptor",
+		"GET /api/v1/tasks/{id}",
+		"HEAD /api/v1/tasks/{id}",
+		"PUT /api/v1/tasks/{id}",
+		"PATCH /api/v1/tasks/{id}",
+		"DELETE /api/v1/tasks/{id}",
+		"GET /api/v1/tasks/{id}/exists",
+		"GET /api/v1/tasks/{id}/next",
+		"GET /api/v1/tasks/{id}/prev",
+		"GET /api/v1/tasks/{id}/blockers",
+		"POST /api/v1/tasks/{id}/assign",
+		"POST /api/v1/tasks/{id}/unassign",
+		"POST /api/v1/tasks/{id}/archive",
+		"POST /api/v1/tasks/{id}/unarchive",
+		"POST /api/v1/tasks/{id}/complete",
+		"POST /api/v1/tasks/{id}/reopen",
+	}
+
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("expected routes\n%v\ngot\n%v", want, got)
+	}
+}
+
+// TestNewRouterDisabledMethods tests that disabled methods answer 405 while others still route
+func TestNewRouterDisabledMethods(t *testing.T) {
+	router := setupRouter(http.MethodGet)
+
+	tests := []struct {
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{http.MethodGet, "/version", http.StatusOK},
+		{http.MethodGet, "/api/v1/tasks/schema", http.StatusOK},
+		{http.MethodPost, "/api/v1/tasks", http.StatusMethodNotAllowed},
+		{http.MethodDelete, "/api/v1/tasks/550e8400-e29b-41d4-a716-446655440000", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/api/v1/unknown", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestNewRouterDisabledFeatures tests that routes of disabled features are not served
+func TestNewRouterDisabledFeatures(t *testing.T) {
+	router := setupRouterWithFeatures(config.SupportedMethods, []string{"lookup"})
+
+	var got []string
+	err := chi.Walk(router.(chi.Routes), func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		got = append(got, method+" "+route)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("chi.Walk() returned error: %v", err)
+	}
+	for _, route := range []string{"GET /api/v1/tasks/{id}/next", "GET /api/v1/tasks/{id}/exists"} {
+		if slices.Contains(got, route) {
+			t.Errorf("expected %s to be absent, got routes %v", route, got)
+		}
+	}
+
+	tests := []struct {
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{http.MethodPost, "/api/v1/tasks/batch", http.StatusNotFound},
+		{http.MethodPatch, "/api/v1/tasks/batch", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/tasks/export", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/tasks/count-by?field=completed", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/tasks/550e8400-e29b-41d4-a716-446655440000/next", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/tasks/schema", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+// TestNewRouterCapabilities tests that capabilities reflect the configuration
+func TestNewRouterCapabilities(t *testing.T) {
+	router := setupRouterWithFeatures([]string{http.MethodGet}, []string{"lookup", "export"})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/capabilities", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var got handlers.ServerCapabilities
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if !slices.Equal(got.Methods, []string{http.MethodGet}) {
+		t.Errorf("expected methods [GET], got %v", got.Methods)
+	}
+	if !slices.Equal(got.Features, []string{"lookup", "export"}) {
+		t.Errorf("expected features [lookup export], got %v", got.Features)
+	}
+	if got.Limits.MaxTitleLength != 100 || got.Limits.MaxDescriptionLength != 500 {
+		t.Errorf("expected title and description limits 100 and 500, got %+v", got.Limits)
+	}
+	if !slices.Contains(got.ContentTypes.Response, "application/x-ndjson") {
+		t.Errorf("expected NDJSON among response types, got %v", got.ContentTypes.Response)
+	}
+}
+
+// TestNewRouterMiddlewares tests that injected middleware wraps the API, in
+// order, and may answer requests itself
+func TestNewRouterMiddlewares(t *testing.T) {
+	var calls []string
+	trace := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "trace")
+			next.ServeHTTP(w, r)
+		})
+	}
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "auth")
+			if r.Header.Get("Authorization") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	router := setupRouterWithFeatures(config.SupportedMethods, config.SupportedFeatures, trace, auth)
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		wantStatus    int
+		wantCalls     []string
+	}{
+		{"rejected", "/api/v1/capabilities", "", http.StatusUnauthorized, []string{"trace", "auth"}},
+		{"let through", "/api/v1/capabilities", "Bearer secret", http.StatusOK, []string{"trace", "auth"}},
+		{"outside the API", "/health", "", http.StatusOK, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("expected middleware calls %v, got %v", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+// tenantDatabase implements database.Database; its repository records the
+// tenant Exists is called for
+type tenantDatabase struct {
+	stubDatabase
+	repo *tenantRepository
+}
+
+func (d tenantDatabase) GetTaskRepository() database.TaskRepository { return d.repo }
+
+type tenantRepository struct {
+	database.TaskRepository
+	calls   int
+	tenants []string
+}
+
+func (r *tenantRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.calls++
+	if tenant, ok := database.TenantFromContext(ctx); ok {
+		r.tenants = append(r.tenants, tenant)
+	}
+	return true, nil
+}
+
+// TestNewRouterTenantAfterAuth tests that injected authentication runs before
+// the tenant header can select a shard, and that a tenant it sets wins
+func TestNewRouterTenantAfterAuth(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := database.TenantFromContext(r.Context()); ok {
+				t.Error("expected no tenant before authentication")
+			}
+			switch r.Header.Get("Authorization") {
+			case "Bearer acme":
+				r = r.WithContext(database.WithTenant(r.Context(), "acme"))
+			case "":
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantTenants   []string
+	}{
+		{"unauthenticated", "", http.StatusUnauthorized, nil},
+		{"authenticated tenant", "Bearer acme", http.StatusOK, []string{"acme"}},
+		{"header only", "Bearer service", http.StatusOK, []string{"globex"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &tenantRepository{}
+			cfg := &config.Config{
+				EnabledMethods: config.SupportedMethods,
+				Features:       config.SupportedFeatures,
+				TenantShards:   map[string]string{"acme": "mongodb://acme", "globex": "mongodb://globex"},
+				TenantHeader:   "X-Tenant-ID",
+			}
+			router := NewRouter(tenantDatabase{repo: repo}, logger, cfg, handlers.BuildInfo{Version: "test"},
+				handlers.NewHealthHandler(stubDatabase{}, logger), auth)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/"+uuid.NewString()+"/exists", nil)
+			req.Header.Set("X-Tenant-ID", "globex")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK && repo.calls > 0 {
+				t.Errorf("expected no repository call, got %d", repo.calls)
+			}
+			if !slices.Equal(repo.tenants, tt.wantTenants) {
+				t.Errorf("expected tenants %v, got %v", tt.wantTenants, repo.tenants)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/PinceredCoder/restGo/internal/config"
+)
+
+// NewHTTPServer wraps handler in an http.Server with the configured timeouts
+// and header limit, so slow or oversized requests cannot hold connections
+// open indefinitely.
+func NewHTTPServer(cfg *config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+}